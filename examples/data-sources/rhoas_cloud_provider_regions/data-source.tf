@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_cloud_provider_regions" "aws" {
+  id           = "aws"
+  enabled_only = true
+}
+
+locals {
+  # the regions with capacity left for a standard instance
+  standard_regions = [
+    for region in data.rhoas_cloud_provider_regions.aws.regions : region.id
+    if length(flatten([
+      for capacity in region.capacity : capacity.available_sizes if capacity.instance_type == "standard"
+    ])) > 0
+  ]
+}
+
+output "standard_regions" {
+  value = local.standard_regions
+}
@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_cloud_providers" "enabled" {
+  enabled_only = true
+}
+
+output "cloud_providers" {
+  value = data.rhoas_cloud_providers.enabled.cloud_providers[*].id
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafkas" "all" {
+}
+
+data "rhoas_consumer_group" "billing" {
+  kafka_id = data.rhoas_kafkas.all.kafkas[0].id
+  group_id = "billing"
+}
+
+output "billing_lag" {
+  value = data.rhoas_consumer_group.billing.total_lag
+}
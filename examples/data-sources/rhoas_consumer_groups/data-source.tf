@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafkas" "all" {
+}
+
+data "rhoas_consumer_groups" "billing" {
+  kafka_id        = data.rhoas_kafkas.all.kafkas[0].id
+  group_id_prefix = "billing-"
+  topic           = "prices"
+}
+
+output "billing_lag" {
+  value = { for group in data.rhoas_consumer_groups.billing.consumer_groups : group.group_id => group.total_lag }
+}
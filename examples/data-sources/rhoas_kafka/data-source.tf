@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "prices" {
+  name = "prices"
+}
+
+output "prices_bootstrap_server_host" {
+  value = data.rhoas_kafka.prices.bootstrap_server_host
+}
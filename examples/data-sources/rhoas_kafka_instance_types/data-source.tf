@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka_instance_types" "aws_us_east_1" {
+  cloud_provider = "aws"
+  region         = "us-east-1"
+}
+
+output "available_sizes" {
+  value = {
+    for instance_type in data.rhoas_kafka_instance_types.aws_us_east_1.instance_types : instance_type.id => [
+      for size in instance_type.sizes : size.id if size.available
+    ]
+  }
+}
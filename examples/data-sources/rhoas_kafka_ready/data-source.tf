@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_kafka" "prices" {
+  name = "prices"
+}
+
+data "rhoas_kafka_ready" "prices" {
+  id = data.rhoas_kafka.prices.id
+
+  timeouts {
+    read = "30m"
+  }
+}
+
+resource "rhoas_topic" "quotes" {
+  name       = "quotes"
+  partitions = 1
+  kafka_id   = data.rhoas_kafka_ready.prices.id
+}
+
+output "bootstrap_server_prices" {
+  value = data.rhoas_kafka_ready.prices.bootstrap_server_host
+}
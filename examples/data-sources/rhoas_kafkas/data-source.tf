@@ -14,3 +14,13 @@ data "rhoas_kafkas" "all" {
 output "all_kafkas" {
   value = data.rhoas_kafkas.all
 }
+
+data "rhoas_kafkas" "ready_prices" {
+  search   = "name like prices%"
+  status   = "ready"
+  order_by = "name asc"
+}
+
+output "ready_prices_total" {
+  value = data.rhoas_kafkas.ready_prices.total
+}
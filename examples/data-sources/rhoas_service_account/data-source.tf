@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+data "rhoas_service_account" "billing" {
+  name = "billing"
+}
+
+output "billing_client_id" {
+  value = data.rhoas_service_account.billing.client_id
+}
@@ -0,0 +1,3 @@
+# ACL bindings can be imported using the id of the Kafka instance and every field of the binding in the form
+# <kafka_id>/<resource_type>/<resource_name>/<pattern_type>/<principal>/<operation>/<permission>
+terraform import rhoas_acl.write_prices c8jd7k0o6j2l0j6b0k5g/TOPIC/prices-/PREFIXED/User:srvc-acct-1/WRITE/ALLOW
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_service_account" "foo" {
+  name        = "foo"
+  description = "producer for the prices topics"
+}
+
+resource "rhoas_acl" "write_prices" {
+  kafka_id      = rhoas_kafka.foo.id
+  resource_type = "TOPIC"
+  resource_name = "prices-"
+  pattern_type  = "PREFIXED"
+  principal     = "User:${rhoas_service_account.foo.client_id}"
+  operation     = "WRITE"
+  permission    = "ALLOW"
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_consumer_group_offset_reset" "billing" {
+  kafka_id = rhoas_kafka.foo.id
+  group_id = "billing"
+  offset   = "timestamp"
+  value    = "2022-06-01T10:00:00Z"
+
+  topic {
+    name       = "prices"
+    partitions = [0, 1]
+  }
+
+  # change the incident number to reset the offsets again
+  triggers = {
+    incident = "INC-1234"
+  }
+}
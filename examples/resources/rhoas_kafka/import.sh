@@ -0,0 +1,2 @@
+# Kafka instances can be imported using their id
+terraform import rhoas_kafka.foo c8jd7k0o6j2l0j6b0k5g
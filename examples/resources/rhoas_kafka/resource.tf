@@ -8,12 +8,14 @@ terraform {
 
 provider "rhoas" {}
 
-/*resource "rhoas_kafka" "foo" {
-  kafka {
-    name = "foo"
-  }
+resource "rhoas_kafka" "foo" {
+  name           = "foo"
+  cloud_provider = "aws"
+  region         = "us-east-1"
+  plan           = "standard.x1"
+  billing_model  = "standard"
 }
 
 output "bootstrap_server_foo" {
-  value = rhoas_kafka.foo.kafka[0].bootstrap_server_host
-}*/
+  value = rhoas_kafka.foo.bootstrap_server_host
+}
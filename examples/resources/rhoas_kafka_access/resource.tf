@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    rhoas = {
+      source  = "pmuir/rhoas"
+    }
+  }
+}
+
+provider "rhoas" {}
+
+resource "rhoas_kafka" "foo" {
+  name = "foo"
+}
+
+resource "rhoas_service_account" "foo" {
+  name        = "foo"
+  description = "producer and consumer of the prices topics"
+}
+
+resource "rhoas_kafka_access" "prices" {
+  kafka_id     = rhoas_kafka.foo.id
+  client_id    = rhoas_service_account.foo.client_id
+  topic_prefix = "prices-"
+  group        = "billing"
+  producer     = true
+  consumer     = true
+}
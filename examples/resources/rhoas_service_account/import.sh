@@ -0,0 +1,4 @@
+# Service accounts can be imported using their id. The client secret is only returned
+# when the service account is created or its secret is rotated, so it is empty for imported
+# service accounts until the secret is rotated.
+terraform import rhoas_service_account.foo 0b3ba5d2-5e5c-4e2f-bf0d-9a8a1d6e8f54
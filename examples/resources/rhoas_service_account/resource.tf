@@ -9,17 +9,21 @@ terraform {
 provider "rhoas" {}
 
 resource "rhoas_service_account" "foo" {
-  service_account {
-    name = "foo"
-    description = "blah blah blah"
+  name        = "foo"
+  description = "blah blah blah"
+
+  # rotate the client secret every 90 days, or earlier by changing the trigger
+  rotate_after_days = 90
+  rotation_triggers = {
+    revision = "1"
   }
 }
 
 output "client_id" {
-  value = rhoas_service_account.foo.service_account[0].client_id
+  value = rhoas_service_account.foo.client_id
 }
 
 output "client_secret" {
-  value = rhoas_service_account.foo.service_account[0].client_secret
+  value = rhoas_service_account.foo.client_secret
   sensitive = true
 }
@@ -0,0 +1,3 @@
+# Topics can be imported using the id of the Kafka instance and the name of the topic. The config
+# of the topic is not imported, so any keys set in the config are applied again on the next apply.
+terraform import rhoas_topic.foo c8jd7k0o6j2l0j6b0k5g/prices
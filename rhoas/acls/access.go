@@ -0,0 +1,113 @@
+package acls
+
+import (
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+)
+
+// AllResourcesName is used as the resource name of an ACL binding that applies to all resources of a type
+const AllResourcesName = "*"
+
+// accessRequest describes the access a principal is granted to the topics and consumer groups of a kafka instance
+type accessRequest struct {
+	principal   string
+	topic       string
+	topicPrefix bool
+	group       string
+	groupPrefix bool
+	producer    bool
+	consumer    bool
+}
+
+// grantAccessBindings expands an access request into the ACL bindings that are needed for it, in the same way as
+// `rhoas kafka acl grant-access` does. Producers can describe, write and create the topics and use transactions,
+// consumers can describe and read the topics and read the consumer groups.
+func grantAccessBindings(request *accessRequest) []kafkainstanceclient.AclBinding {
+	var bindings []kafkainstanceclient.AclBinding
+
+	topicPatternType := patternType(request.topicPrefix)
+	groupPatternType := patternType(request.groupPrefix)
+
+	addBinding := func(resourceType kafkainstanceclient.AclResourceType, resourceName string, patternType kafkainstanceclient.AclPatternType, operation kafkainstanceclient.AclOperation) {
+		binding := kafkainstanceclient.NewAclBinding(resourceType, resourceName, patternType, request.principal, operation, kafkainstanceclient.ACLPERMISSIONTYPE_ALLOW)
+		for i := range bindings {
+			if aclBindingsEqual(&bindings[i], binding) {
+				return
+			}
+		}
+		bindings = append(bindings, *binding)
+	}
+
+	if request.producer {
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TOPIC, request.topic, topicPatternType, kafkainstanceclient.ACLOPERATION_DESCRIBE)
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TOPIC, request.topic, topicPatternType, kafkainstanceclient.ACLOPERATION_WRITE)
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TOPIC, request.topic, topicPatternType, kafkainstanceclient.ACLOPERATION_CREATE)
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TRANSACTIONAL_ID, AllResourcesName, kafkainstanceclient.ACLPATTERNTYPE_LITERAL, kafkainstanceclient.ACLOPERATION_WRITE)
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TRANSACTIONAL_ID, AllResourcesName, kafkainstanceclient.ACLPATTERNTYPE_LITERAL, kafkainstanceclient.ACLOPERATION_DESCRIBE)
+	}
+
+	if request.consumer {
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TOPIC, request.topic, topicPatternType, kafkainstanceclient.ACLOPERATION_DESCRIBE)
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_TOPIC, request.topic, topicPatternType, kafkainstanceclient.ACLOPERATION_READ)
+		addBinding(kafkainstanceclient.ACLRESOURCETYPE_GROUP, request.group, groupPatternType, kafkainstanceclient.ACLOPERATION_READ)
+	}
+
+	return bindings
+}
+
+func patternType(prefix bool) kafkainstanceclient.AclPatternType {
+	if prefix {
+		return kafkainstanceclient.ACLPATTERNTYPE_PREFIXED
+	}
+	return kafkainstanceclient.ACLPATTERNTYPE_LITERAL
+}
+
+// flattenACLBindings converts ACL bindings into the list representation used in the schema
+func flattenACLBindings(bindings []kafkainstanceclient.AclBinding) []interface{} {
+	flattened := make([]interface{}, 0, len(bindings))
+	for i := range bindings {
+		flattened = append(flattened, map[string]interface{}{
+			"resource_type": string(bindings[i].GetResourceType()),
+			"resource_name": bindings[i].GetResourceName(),
+			"pattern_type":  string(bindings[i].GetPatternType()),
+			"principal":     bindings[i].GetPrincipal(),
+			"operation":     string(bindings[i].GetOperation()),
+			"permission":    string(bindings[i].GetPermission()),
+		})
+	}
+	return flattened
+}
+
+// expandACLBindings converts the list representation used in the schema into ACL bindings
+func expandACLBindings(raw []interface{}) ([]kafkainstanceclient.AclBinding, error) {
+	bindings := make([]kafkainstanceclient.AclBinding, 0, len(raw))
+	for _, item := range raw {
+		values, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		strs := map[string]string{}
+		for key, value := range values {
+			if str, ok := value.(string); ok {
+				strs[key] = str
+			}
+		}
+
+		binding, err := newACLBinding(strs["resource_type"], strs["resource_name"], strs["pattern_type"], strs["principal"], strs["operation"], strs["permission"])
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, *binding)
+	}
+	return bindings, nil
+}
+
+// containsACLBinding checks whether the binding is part of the list
+func containsACLBinding(bindings []kafkainstanceclient.AclBinding, binding *kafkainstanceclient.AclBinding) bool {
+	for i := range bindings {
+		if aclBindingsEqual(&bindings[i], binding) {
+			return true
+		}
+	}
+	return false
+}
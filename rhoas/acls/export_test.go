@@ -0,0 +1,8 @@
+package acls
+
+// expose the unexported functions of the package to the tests in acls_test
+var (
+	ACLBindingID      = aclBindingID
+	ParseACLBindingID = parseACLBindingID
+	NewACLBinding     = newACLBinding
+)
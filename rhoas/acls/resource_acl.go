@@ -0,0 +1,254 @@
+package acls
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+)
+
+func ResourceACL() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_acl` manages an ACL binding in a Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
+		CreateContext: aclCreate,
+		ReadContext:   aclRead,
+		DeleteContext: aclDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: aclImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"kafka_id": {
+				Description: "The unique ID of the kafka instance this ACL binding is associated with",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"resource_type": {
+				Description: "The type of resource the ACL binding applies to. One of `TOPIC`, `GROUP`, `CLUSTER` or `TRANSACTIONAL_ID`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(kafkainstanceclient.ACLRESOURCETYPE_TOPIC),
+					string(kafkainstanceclient.ACLRESOURCETYPE_GROUP),
+					string(kafkainstanceclient.ACLRESOURCETYPE_CLUSTER),
+					string(kafkainstanceclient.ACLRESOURCETYPE_TRANSACTIONAL_ID),
+				}, false)),
+			},
+			"resource_name": {
+				Description: "The name of the resource the ACL binding applies to, or the prefix of the names when `pattern_type` is `PREFIXED`. Use `*` for all resources of the type and `kafka-cluster` for the `CLUSTER` resource type.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pattern_type": {
+				Description: "How `resource_name` is matched. One of `LITERAL` or `PREFIXED`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     string(kafkainstanceclient.ACLPATTERNTYPE_LITERAL),
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(kafkainstanceclient.ACLPATTERNTYPE_LITERAL),
+					string(kafkainstanceclient.ACLPATTERNTYPE_PREFIXED),
+				}, false)),
+			},
+			"principal": {
+				Description: "The principal the ACL binding applies to in the form `User:<client_id>`. Use `User:*` for all principals.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+					principalRegexp, "the principal must be in the form User:<client_id>")),
+			},
+			"operation": {
+				Description: "The operation the ACL binding applies to. One of `ALL`, `READ`, `WRITE`, `CREATE`, `DELETE`, `ALTER`, `DESCRIBE`, `DESCRIBE_CONFIGS` or `ALTER_CONFIGS`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(kafkainstanceclient.ACLOPERATION_ALL),
+					string(kafkainstanceclient.ACLOPERATION_READ),
+					string(kafkainstanceclient.ACLOPERATION_WRITE),
+					string(kafkainstanceclient.ACLOPERATION_CREATE),
+					string(kafkainstanceclient.ACLOPERATION_DELETE),
+					string(kafkainstanceclient.ACLOPERATION_ALTER),
+					string(kafkainstanceclient.ACLOPERATION_DESCRIBE),
+					string(kafkainstanceclient.ACLOPERATION_DESCRIBE_CONFIGS),
+					string(kafkainstanceclient.ACLOPERATION_ALTER_CONFIGS),
+				}, false)),
+			},
+			"permission": {
+				Description: "Whether the operation is allowed or denied. One of `ALLOW` or `DENY`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(kafkainstanceclient.ACLPERMISSIONTYPE_ALLOW),
+					string(kafkainstanceclient.ACLPERMISSIONTYPE_DENY),
+				}, false)),
+			},
+		},
+	}
+}
+
+func aclDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, binding, err := mapResourceDataToACLBinding(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		return deleteACLBinding(ctx, instanceAPI, binding)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func aclRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, binding, err := mapResourceDataToACLBinding(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var exists bool
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		var err error
+		exists, err = aclBindingExists(ctx, instanceAPI, binding)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !exists {
+		log.Printf("[WARN] ACL binding %s not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	return diags
+}
+
+func aclCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, binding, err := mapResourceDataToACLBinding(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		return createACLBinding(ctx, instanceAPI, binding)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(aclBindingID(kafkaID, binding))
+
+	return diags
+}
+
+func aclImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	kafkaID, binding, err := parseACLBindingID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	err = setResourceDataFromACLBinding(d, kafkaID, binding)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setResourceDataFromACLBinding(d *schema.ResourceData, kafkaID string, binding *kafkainstanceclient.AclBinding) error {
+	var err error
+
+	if err = d.Set("kafka_id", kafkaID); err != nil {
+		return err
+	}
+
+	if err = d.Set("resource_type", string(binding.GetResourceType())); err != nil {
+		return err
+	}
+
+	if err = d.Set("resource_name", binding.GetResourceName()); err != nil {
+		return err
+	}
+
+	if err = d.Set("pattern_type", string(binding.GetPatternType())); err != nil {
+		return err
+	}
+
+	if err = d.Set("principal", binding.GetPrincipal()); err != nil {
+		return err
+	}
+
+	if err = d.Set("operation", string(binding.GetOperation())); err != nil {
+		return err
+	}
+
+	if err = d.Set("permission", string(binding.GetPermission())); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapResourceDataToACLBinding(d *schema.ResourceData) (string, *kafkainstanceclient.AclBinding, error) {
+	values := map[string]string{}
+
+	for _, key := range []string{"kafka_id", "resource_type", "resource_name", "pattern_type", "principal", "operation", "permission"} {
+		value, ok := d.Get(key).(string)
+		if !ok {
+			return "", nil, errors.Errorf("There was a problem getting the %s value in the schema resource", key)
+		}
+		values[key] = value
+	}
+
+	binding, err := newACLBinding(values["resource_type"], values["resource_name"], values["pattern_type"], values["principal"], values["operation"], values["permission"])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return values["kafka_id"], binding, nil
+}
@@ -0,0 +1,436 @@
+package acls
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+)
+
+func ResourceKafkaAccess() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_kafka_access` grants a service account producer and/or consumer access to the topics and consumer groups of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, in the same way as `rhoas kafka acl grant-access`. The access is expanded into a set of ACL bindings that are managed as one unit. ACL bindings are not reference counted, so two `rhoas_kafka_access` resources that expand into the same binding should not be used for the same service account, as destroying one removes the binding for both.",
+		CreateContext: kafkaAccessCreate,
+		ReadContext:   kafkaAccessRead,
+		UpdateContext: kafkaAccessUpdate,
+		DeleteContext: kafkaAccessDelete,
+		CustomizeDiff: kafkaAccessCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"kafka_id": {
+				Description: "The unique ID of the kafka instance the access is granted to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"client_id": {
+				Description: "The client id of the service account the access is granted to. Use `*` to grant the access to all service accounts and users.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"topic": {
+				Description: "The name of the topic the access is granted to. Use `*` for all topics. Exactly one of `topic` or `topic_prefix` must be set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ExactlyOneOf: []string{
+					"topic",
+					"topic_prefix",
+				},
+			},
+			"topic_prefix": {
+				Description: "The prefix of the names of the topics the access is granted to. Exactly one of `topic` or `topic_prefix` must be set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"group": {
+				Description: "The id of the consumer group the access is granted to. Use `*` for all consumer groups. One of `group` or `group_prefix` must be set when `consumer` is `true`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ConflictsWith: []string{
+					"group_prefix",
+				},
+			},
+			"group_prefix": {
+				Description: "The prefix of the ids of the consumer groups the access is granted to. One of `group` or `group_prefix` must be set when `consumer` is `true`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"producer": {
+				Description: "Whether the service account can produce messages to the topics. At least one of `producer` or `consumer` must be `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"consumer": {
+				Description: "Whether the service account can consume messages from the topics using the consumer groups. At least one of `producer` or `consumer` must be `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"acl_bindings": {
+				Description: "The ACL bindings the access is expanded into. A binding that is removed outside of terraform is created again on the next apply.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Description: "The type of resource the ACL binding applies to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"resource_name": {
+							Description: "The name of the resource the ACL binding applies to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"pattern_type": {
+							Description: "How `resource_name` is matched",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"principal": {
+							Description: "The principal the ACL binding applies to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"operation": {
+							Description: "The operation the ACL binding applies to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"permission": {
+							Description: "Whether the operation is allowed or denied",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kafkaAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	bindings, err := getStateACLBindings(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		// the bindings deleted by an earlier attempt are not deleted again
+		for len(bindings) > 0 {
+			if err := deleteACLBinding(ctx, instanceAPI, &bindings[0]); err != nil {
+				return err
+			}
+			bindings = bindings[1:]
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func kafkaAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	bindings, err := getStateACLBindings(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only keep the bindings that still exist, so that the diff against the expected bindings shows the missing ones
+	existing := make([]kafkainstanceclient.AclBinding, 0, len(bindings))
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		existing = existing[:0]
+		for i := range bindings {
+			exists, err := aclBindingExists(ctx, instanceAPI, &bindings[i])
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				log.Printf("[WARN] ACL binding %s of %s not found, it will be created again", aclBindingID(kafkaID, &bindings[i]), d.Id())
+				continue
+			}
+			existing = append(existing, bindings[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("acl_bindings", flattenACLBindings(existing)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func kafkaAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	request, err := mapResourceDataToAccessRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the id is set before the bindings are created, so that a partially created set of bindings is tracked in the
+	// state and removed when the tainted resource is destroyed
+	d.SetId(resource.PrefixedUniqueId(fmt.Sprintf("%s/", kafkaID)))
+
+	bindings := grantAccessBindings(request)
+
+	var created []kafkainstanceclient.AclBinding
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		// the bindings created by an earlier attempt are not created again
+		for i := len(created); i < len(bindings); i++ {
+			if err := createACLBinding(ctx, instanceAPI, &bindings[i]); err != nil {
+				return err
+			}
+			created = append(created, bindings[i])
+		}
+		return nil
+	})
+
+	// nothing was created, so there is nothing to track in the state
+	if err != nil && len(created) == 0 {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	if setErr := d.Set("acl_bindings", flattenACLBindings(created)); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func kafkaAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	request, err := mapResourceDataToAccessRequest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldValue, _ := d.GetChange("acl_bindings")
+	oldList, ok := oldValue.([]interface{})
+	if !ok {
+		return diag.Errorf("There was a problem getting the acl bindings value in the schema resource")
+	}
+
+	current, err := expandACLBindings(oldList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	expected := grantAccessBindings(request)
+
+	// current always holds the bindings that exist, so that an attempt carries on where the previous one stopped
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		// create the missing bindings first, so that the service account does not lose access while it is updated
+		for i := range expected {
+			if containsACLBinding(current, &expected[i]) {
+				continue
+			}
+
+			if err := createACLBinding(ctx, instanceAPI, &expected[i]); err != nil {
+				return err
+			}
+			current = append(current, expected[i])
+		}
+
+		for i := 0; i < len(current); {
+			if containsACLBinding(expected, &current[i]) {
+				i++
+				continue
+			}
+
+			if err := deleteACLBinding(ctx, instanceAPI, &current[i]); err != nil {
+				return err
+			}
+			current = append(current[:i], current[i+1:]...)
+		}
+		return nil
+	})
+
+	if setErr := d.Set("acl_bindings", flattenACLBindings(current)); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// kafkaAccessCustomizeDiff validates the combination of the access arguments and plans the ACL bindings they expand
+// into, so that any binding that differs from the expanded set shows up in the plan
+func kafkaAccessCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, key := range []string{"client_id", "topic", "topic_prefix", "group", "group_prefix", "producer", "consumer"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("acl_bindings")
+		}
+	}
+
+	request, err := mapToAccessRequest(d)
+	if err != nil {
+		return err
+	}
+
+	if err = validateAccessRequest(request); err != nil {
+		return err
+	}
+
+	return d.SetNew("acl_bindings", flattenACLBindings(grantAccessBindings(request)))
+}
+
+// validateAccessRequest checks the access arguments that cannot be validated by the schema alone
+func validateAccessRequest(request *accessRequest) error {
+	if !request.producer && !request.consumer {
+		return errors.Errorf("at least one of producer or consumer must be true")
+	}
+
+	if request.consumer && request.group == "" {
+		return errors.Errorf("one of group or group_prefix must be set when consumer is true")
+	}
+
+	return nil
+}
+
+// accessGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type accessGetter interface {
+	Get(key string) interface{}
+}
+
+func mapResourceDataToAccessRequest(d *schema.ResourceData) (*accessRequest, error) {
+	request, err := mapToAccessRequest(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateAccessRequest(request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func mapToAccessRequest(d accessGetter) (*accessRequest, error) {
+	values := map[string]string{}
+	for _, key := range []string{"client_id", "topic", "topic_prefix", "group", "group_prefix"} {
+		value, ok := d.Get(key).(string)
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the %s value in the schema resource", key)
+		}
+		values[key] = value
+	}
+
+	producer, ok := d.Get("producer").(bool)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the producer value in the schema resource")
+	}
+
+	consumer, ok := d.Get("consumer").(bool)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the consumer value in the schema resource")
+	}
+
+	request := &accessRequest{
+		principal: PrincipalPrefix + values["client_id"],
+		topic:     values["topic"],
+		group:     values["group"],
+		producer:  producer,
+		consumer:  consumer,
+	}
+
+	if values["topic_prefix"] != "" {
+		request.topic = values["topic_prefix"]
+		request.topicPrefix = true
+	}
+
+	if values["group_prefix"] != "" {
+		request.group = values["group_prefix"]
+		request.groupPrefix = true
+	}
+
+	return request, nil
+}
+
+// getStateACLBindings returns the ACL bindings that are tracked in the state of the resource
+func getStateACLBindings(d *schema.ResourceData) ([]kafkainstanceclient.AclBinding, error) {
+	raw, ok := d.Get("acl_bindings").([]interface{})
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the acl bindings value in the schema resource")
+	}
+
+	return expandACLBindings(raw)
+}
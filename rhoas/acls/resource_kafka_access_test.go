@@ -0,0 +1,164 @@
+package acls_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/acls"
+)
+
+func TestKafkaAccessCustomizeDiff(t *testing.T) {
+	resource := acls.ResourceKafkaAccess()
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name: "producer",
+			config: map[string]interface{}{
+				"kafka_id":  "c8jd7k0o6j2l0j6b0k5g",
+				"client_id": "srvc-acct-1",
+				"topic":     "prices",
+				"producer":  true,
+			},
+			expected: []string{
+				"TOPIC/prices/LITERAL/User:srvc-acct-1/DESCRIBE/ALLOW",
+				"TOPIC/prices/LITERAL/User:srvc-acct-1/WRITE/ALLOW",
+				"TOPIC/prices/LITERAL/User:srvc-acct-1/CREATE/ALLOW",
+				"TRANSACTIONAL_ID/*/LITERAL/User:srvc-acct-1/WRITE/ALLOW",
+				"TRANSACTIONAL_ID/*/LITERAL/User:srvc-acct-1/DESCRIBE/ALLOW",
+			},
+		},
+		{
+			name: "consumer with prefixes",
+			config: map[string]interface{}{
+				"kafka_id":     "c8jd7k0o6j2l0j6b0k5g",
+				"client_id":    "srvc-acct-1",
+				"topic_prefix": "prices-",
+				"group_prefix": "billing-",
+				"consumer":     true,
+			},
+			expected: []string{
+				"TOPIC/prices-/PREFIXED/User:srvc-acct-1/DESCRIBE/ALLOW",
+				"TOPIC/prices-/PREFIXED/User:srvc-acct-1/READ/ALLOW",
+				"GROUP/billing-/PREFIXED/User:srvc-acct-1/READ/ALLOW",
+			},
+		},
+		{
+			name: "producer and consumer",
+			config: map[string]interface{}{
+				"kafka_id":  "c8jd7k0o6j2l0j6b0k5g",
+				"client_id": "*",
+				"topic":     "*",
+				"group":     "*",
+				"producer":  true,
+				"consumer":  true,
+			},
+			expected: []string{
+				"TOPIC/*/LITERAL/User:*/DESCRIBE/ALLOW",
+				"TOPIC/*/LITERAL/User:*/WRITE/ALLOW",
+				"TOPIC/*/LITERAL/User:*/CREATE/ALLOW",
+				"TRANSACTIONAL_ID/*/LITERAL/User:*/WRITE/ALLOW",
+				"TRANSACTIONAL_ID/*/LITERAL/User:*/DESCRIBE/ALLOW",
+				"TOPIC/*/LITERAL/User:*/READ/ALLOW",
+				"GROUP/*/LITERAL/User:*/READ/ALLOW",
+			},
+		},
+		{
+			name: "neither producer nor consumer",
+			config: map[string]interface{}{
+				"kafka_id":  "c8jd7k0o6j2l0j6b0k5g",
+				"client_id": "srvc-acct-1",
+				"topic":     "prices",
+			},
+			wantErr: true,
+		},
+		{
+			name: "consumer without group",
+			config: map[string]interface{}{
+				"kafka_id":  "c8jd7k0o6j2l0j6b0k5g",
+				"client_id": "srvc-acct-1",
+				"topic":     "prices",
+				"consumer":  true,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), nil)
+			if tt.wantErr {
+				assert.Error(t, err, "expected an error for an invalid access")
+				return
+			}
+			assert.NoError(t, err, "got unexpected error while planning the access")
+			assert.Equal(t, tt.expected, plannedBindings(diff), "unexpected ACL bindings were planned")
+		})
+	}
+}
+
+func TestKafkaAccessDrift(t *testing.T) {
+	resource := acls.ResourceKafkaAccess()
+	config := map[string]interface{}{
+		"kafka_id":  "c8jd7k0o6j2l0j6b0k5g",
+		"client_id": "srvc-acct-1",
+		"topic":     "prices",
+		"group":     "billing",
+		"consumer":  true,
+	}
+
+	planned, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err, "got unexpected error while planning the access")
+
+	state := &terraform.InstanceState{ID: "c8jd7k0o6j2l0j6b0k5g/access", Attributes: map[string]string{}}
+	for key, attr := range planned.Attributes {
+		state.Attributes[key] = attr.New
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+		assert.NoError(t, err, "got unexpected error while planning the access")
+		assert.True(t, diff == nil || diff.Empty(), "expected no changes")
+	})
+
+	t.Run("binding removed", func(t *testing.T) {
+		// the read drops bindings that no longer exist from the state
+		drifted := state.DeepCopy()
+		drifted.Attributes["acl_bindings.#"] = "2"
+		for _, key := range []string{"resource_type", "resource_name", "pattern_type", "principal", "operation", "permission"} {
+			delete(drifted.Attributes, fmt.Sprintf("acl_bindings.2.%s", key))
+		}
+
+		diff, err := resource.Diff(context.Background(), drifted, terraform.NewResourceConfigRaw(config), nil)
+		assert.NoError(t, err, "got unexpected error while planning the access")
+		assert.False(t, diff == nil || diff.Empty(), "expected the removed binding to be planned")
+		assert.False(t, diff.RequiresNew(), "a removed binding should not replace the access")
+		assert.Equal(t, "3", diff.Attributes["acl_bindings.#"].New, "expected the removed binding to be planned")
+	})
+}
+
+// plannedBindings returns the planned ACL bindings of a diff in the form
+// <resource_type>/<resource_name>/<pattern_type>/<principal>/<operation>/<permission>
+func plannedBindings(diff *terraform.InstanceDiff) []string {
+	var bindings []string
+	for i := 0; ; i++ {
+		prefix := fmt.Sprintf("acl_bindings.%d.", i)
+		if _, ok := diff.Attributes[prefix+"resource_type"]; !ok {
+			return bindings
+		}
+
+		binding := diff.Attributes[prefix+"resource_type"].New
+		for _, key := range []string{"resource_name", "pattern_type", "principal", "operation", "permission"} {
+			binding += "/" + diff.Attributes[prefix+key].New
+		}
+		bindings = append(bindings, binding)
+	}
+}
@@ -0,0 +1,161 @@
+package acls
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+const (
+	// PrincipalPrefix is required by the API for every principal an ACL binding is bound to
+	PrincipalPrefix = "User:"
+	// ClusterResourceName is the name Kafka uses for the cluster resource
+	ClusterResourceName = "kafka-cluster"
+
+	aclPageSize int32 = 100
+)
+
+var principalRegexp = regexp.MustCompile("^" + PrincipalPrefix + ".+$")
+
+// aclBindingID builds the terraform id of an ACL binding. ACL bindings do not have an id of their own, so the id is
+// made of the kafka instance id and every field of the binding in the form
+// <kafka_id>/<resource_type>/<resource_name>/<pattern_type>/<principal>/<operation>/<permission>
+func aclBindingID(kafkaID string, binding *kafkainstanceclient.AclBinding) string {
+	return strings.Join([]string{
+		kafkaID,
+		string(binding.GetResourceType()),
+		binding.GetResourceName(),
+		string(binding.GetPatternType()),
+		binding.GetPrincipal(),
+		string(binding.GetOperation()),
+		string(binding.GetPermission()),
+	}, "/")
+}
+
+// parseACLBindingID splits a terraform ACL binding id into the kafka instance id and the binding. The resource name
+// may contain slashes itself, so everything between the resource type and the pattern type is the resource name
+func parseACLBindingID(id string) (string, *kafkainstanceclient.AclBinding, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) < 7 {
+		return "", nil, errors.Errorf("unexpected format of ACL id %q, expected <kafka_id>/<resource_type>/<resource_name>/<pattern_type>/<principal>/<operation>/<permission>", id)
+	}
+
+	last := len(parts) - 4
+	binding, err := newACLBinding(parts[1], strings.Join(parts[2:last], "/"), parts[last], parts[last+1], parts[last+2], parts[last+3])
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "unable to parse ACL id %q", id)
+	}
+
+	return parts[0], binding, nil
+}
+
+// newACLBinding creates an ACL binding from its string values, validating that every value is known to the API
+func newACLBinding(resourceType string, resourceName string, patternType string, principal string, operation string, permission string) (*kafkainstanceclient.AclBinding, error) {
+	aclResourceType, err := kafkainstanceclient.NewAclResourceTypeFromValue(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	aclPatternType, err := kafkainstanceclient.NewAclPatternTypeFromValue(patternType)
+	if err != nil {
+		return nil, err
+	}
+
+	aclOperation, err := kafkainstanceclient.NewAclOperationFromValue(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	aclPermission, err := kafkainstanceclient.NewAclPermissionTypeFromValue(permission)
+	if err != nil {
+		return nil, err
+	}
+
+	if resourceName == "" {
+		return nil, errors.Errorf("the resource name of an ACL binding must not be empty")
+	}
+
+	if !strings.HasPrefix(principal, PrincipalPrefix) {
+		return nil, errors.Errorf("the principal %q of an ACL binding must start with %q", principal, PrincipalPrefix)
+	}
+
+	return kafkainstanceclient.NewAclBinding(*aclResourceType, resourceName, *aclPatternType, principal, *aclOperation, *aclPermission), nil
+}
+
+// createACLBinding creates a single ACL binding in the kafka instance
+func createACLBinding(ctx context.Context, instanceAPI *kafkainstanceclient.APIClient, binding *kafkainstanceclient.AclBinding) error {
+	resp, err := instanceAPI.AclsApi.CreateAcl(ctx).AclBinding(*binding).Execute()
+	if err != nil {
+		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+			return apiErr
+		}
+	}
+
+	return nil
+}
+
+// aclBindingExists checks whether an ACL binding that exactly matches the given one exists in the kafka instance
+func aclBindingExists(ctx context.Context, instanceAPI *kafkainstanceclient.APIClient, binding *kafkainstanceclient.AclBinding) (bool, error) {
+	// the server filters the bindings, but a prefixed or wildcard binding may match more than one, so go through
+	// every page until an exact match is found
+	for page := int32(1); ; page++ {
+		list, resp, err := instanceAPI.AclsApi.GetAcls(ctx).
+			ResourceType(kafkainstanceclient.AclResourceTypeFilter(binding.GetResourceType())).
+			ResourceName(binding.GetResourceName()).
+			PatternType(kafkainstanceclient.AclPatternTypeFilter(binding.GetPatternType())).
+			Principal(binding.GetPrincipal()).
+			Operation(kafkainstanceclient.AclOperationFilter(binding.GetOperation())).
+			Permission(kafkainstanceclient.AclPermissionTypeFilter(binding.GetPermission())).
+			Page(page).
+			Size(aclPageSize).
+			Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return false, apiErr
+			}
+		}
+
+		items := list.GetItems()
+		for i := range items {
+			if aclBindingsEqual(&items[i], binding) {
+				return true, nil
+			}
+		}
+
+		if len(items) == 0 || page*aclPageSize >= list.GetTotal() {
+			return false, nil
+		}
+	}
+}
+
+// deleteACLBinding deletes the ACL binding that exactly matches the given one from the kafka instance
+func deleteACLBinding(ctx context.Context, instanceAPI *kafkainstanceclient.APIClient, binding *kafkainstanceclient.AclBinding) error {
+	_, resp, err := instanceAPI.AclsApi.DeleteAcls(ctx).
+		ResourceType(kafkainstanceclient.AclResourceTypeFilter(binding.GetResourceType())).
+		ResourceName(binding.GetResourceName()).
+		PatternType(kafkainstanceclient.AclPatternTypeFilter(binding.GetPatternType())).
+		Principal(binding.GetPrincipal()).
+		Operation(kafkainstanceclient.AclOperationFilter(binding.GetOperation())).
+		Permission(kafkainstanceclient.AclPermissionTypeFilter(binding.GetPermission())).
+		Execute()
+	if err != nil {
+		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+			return apiErr
+		}
+	}
+
+	return nil
+}
+
+func aclBindingsEqual(a *kafkainstanceclient.AclBinding, b *kafkainstanceclient.AclBinding) bool {
+	return a.GetResourceType() == b.GetResourceType() &&
+		a.GetResourceName() == b.GetResourceName() &&
+		a.GetPatternType() == b.GetPatternType() &&
+		a.GetPrincipal() == b.GetPrincipal() &&
+		a.GetOperation() == b.GetOperation() &&
+		a.GetPermission() == b.GetPermission()
+}
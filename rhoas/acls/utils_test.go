@@ -0,0 +1,70 @@
+package acls_test
+
+import (
+	"testing"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/acls"
+)
+
+func TestACLBindingID(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		binding := kafkainstanceclient.NewAclBinding(kafkainstanceclient.ACLRESOURCETYPE_TOPIC, "prices",
+			kafkainstanceclient.ACLPATTERNTYPE_LITERAL, "User:srvc-acct-1", kafkainstanceclient.ACLOPERATION_READ, kafkainstanceclient.ACLPERMISSIONTYPE_ALLOW)
+
+		id := acls.ACLBindingID("c8jd7k0o6j2l0j6b0k5g", binding)
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g/TOPIC/prices/LITERAL/User:srvc-acct-1/READ/ALLOW", id, "unexpected id was returned")
+
+		kafkaID, parsed, err := acls.ParseACLBindingID(id)
+		assert.NoError(t, err, "got unexpected error while parsing a valid ACL id")
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g", kafkaID, "unexpected kafka id was returned")
+		assert.Equal(t, binding, parsed, "unexpected binding was returned")
+	})
+
+	t.Run("round trip with slashes in the resource name", func(t *testing.T) {
+		binding := kafkainstanceclient.NewAclBinding(kafkainstanceclient.ACLRESOURCETYPE_GROUP, "team/payments/",
+			kafkainstanceclient.ACLPATTERNTYPE_PREFIXED, "User:*", kafkainstanceclient.ACLOPERATION_ALL, kafkainstanceclient.ACLPERMISSIONTYPE_DENY)
+
+		kafkaID, parsed, err := acls.ParseACLBindingID(acls.ACLBindingID("c8jd7k0o6j2l0j6b0k5g", binding))
+		assert.NoError(t, err, "got unexpected error while parsing a valid ACL id")
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g", kafkaID, "unexpected kafka id was returned")
+		assert.Equal(t, "team/payments/", parsed.GetResourceName(), "the resource name was not parsed correctly")
+		assert.Equal(t, binding, parsed, "unexpected binding was returned")
+	})
+
+	t.Run("too few parts", func(t *testing.T) {
+		_, _, err := acls.ParseACLBindingID("c8jd7k0o6j2l0j6b0k5g/TOPIC/prices")
+		assert.Error(t, err, "expected an error while parsing an incomplete ACL id")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, _, err := acls.ParseACLBindingID("c8jd7k0o6j2l0j6b0k5g/TOPIC/prices/LITERAL/User:srvc-acct-1/READ/MAYBE")
+		assert.Error(t, err, "expected an error while parsing an ACL id with an invalid permission")
+	})
+}
+
+func TestNewACLBinding(t *testing.T) {
+	t.Run("valid binding", func(t *testing.T) {
+		binding, err := acls.NewACLBinding("TOPIC", "prices", "LITERAL", "User:srvc-acct-1", "WRITE", "ALLOW")
+		assert.NoError(t, err, "got unexpected error while creating a valid binding")
+		assert.Equal(t, kafkainstanceclient.ACLOPERATION_WRITE, binding.GetOperation(), "unexpected operation was returned")
+	})
+
+	invalid := map[string][]string{
+		"resource type": {"QUEUE", "prices", "LITERAL", "User:srvc-acct-1", "WRITE", "ALLOW"},
+		"pattern type":  {"TOPIC", "prices", "MATCH", "User:srvc-acct-1", "WRITE", "ALLOW"},
+		"operation":     {"TOPIC", "prices", "LITERAL", "User:srvc-acct-1", "PUBLISH", "ALLOW"},
+		"permission":    {"TOPIC", "prices", "LITERAL", "User:srvc-acct-1", "WRITE", "MAYBE"},
+		"resource name": {"TOPIC", "", "LITERAL", "User:srvc-acct-1", "WRITE", "ALLOW"},
+		"principal":     {"TOPIC", "prices", "LITERAL", "srvc-acct-1", "WRITE", "ALLOW"},
+	}
+
+	for name, values := range invalid {
+		values := values
+		t.Run("invalid "+name, func(t *testing.T) {
+			_, err := acls.NewACLBinding(values[0], values[1], values[2], values[3], values[4], values[5])
+			assert.Error(t, err, "expected an error while creating a binding with an invalid %s", name)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	svcacctmgmtclient "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
 	"net/http"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
 )
 
 type Clients interface {
@@ -13,4 +14,10 @@ type Clients interface {
 	ServiceAccountMgmt() svcacctmgmtclient.ServiceAccountsApi
 	KafkaAdmin(ctx *context.Context, instanceID string) (*kafkainstanceclient.APIClient, *kafkamgmtclient.KafkaRequest, error)
 	HTTPClient() *http.Client
+	// KafkaDeletionProtectionPatterns returns the name patterns of the Kafka instances that must never be deleted
+	KafkaDeletionProtectionPatterns() []string
+	// Username returns the username of the user or service account the provider is authenticated as
+	Username() (string, error)
+	// WaitConfig returns how the API is polled while waiting for an object to change state
+	WaitConfig() utils.WaitConfig
 }
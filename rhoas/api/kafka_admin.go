@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+)
+
+// RetryKafkaAdmin calls f with the admin API client of the Kafka instance. Getting the client and calling f are
+// retried until the deadline of ctx while the Kafka instance is still being created or the admin API fails with a
+// transient error, so f must be safe to call again after a failure.
+func RetryKafkaAdmin(ctx context.Context, clients Clients, instanceID string, f func(instanceAPI *kafkainstanceclient.APIClient) error) error {
+	return clients.WaitConfig().Retry(ctx, func() error {
+		instanceAPI, _, err := clients.KafkaAdmin(&ctx, instanceID)
+		if err != nil {
+			return err
+		}
+
+		return f(instanceAPI)
+	})
+}
@@ -2,13 +2,18 @@ package clients
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	kafkainstance "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1"
 	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	kafkamgmtv1errors "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/error"
 	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	"golang.org/x/oauth2"
 	"net/http"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+	"strings"
 )
 
 type ServiceStatus = string
@@ -27,13 +32,21 @@ type DefaultClient struct {
 	kafkaClient          *kafkamgmtclient.APIClient
 	serviceAccountClient *serviceAccounts.APIClient
 	httpClient           *http.Client
+	// kafkaAdminURL overrides the admin API URL reported by the Kafka instances when set
+	kafkaAdminURL string
+	// kafkaDeletionProtectionPatterns are the name patterns of the Kafka instances that must never be deleted
+	kafkaDeletionProtectionPatterns []string
+	waitConfig                      utils.WaitConfig
 }
 
-func NewDefaultClient(kafkaClient *kafkamgmtclient.APIClient, serviceAccountClient *serviceAccounts.APIClient, httpClient *http.Client) *DefaultClient {
+func NewDefaultClient(kafkaClient *kafkamgmtclient.APIClient, serviceAccountClient *serviceAccounts.APIClient, httpClient *http.Client, kafkaAdminURL string, kafkaDeletionProtectionPatterns []string, waitConfig utils.WaitConfig) *DefaultClient {
 	return &DefaultClient{
-		kafkaClient:          kafkaClient,
-		serviceAccountClient: serviceAccountClient,
-		httpClient:           httpClient,
+		kafkaClient:                     kafkaClient,
+		serviceAccountClient:            serviceAccountClient,
+		httpClient:                      httpClient,
+		kafkaAdminURL:                   kafkaAdminURL,
+		kafkaDeletionProtectionPatterns: kafkaDeletionProtectionPatterns,
+		waitConfig:                      waitConfig,
 	}
 }
 
@@ -57,12 +70,17 @@ func (c *DefaultClient) KafkaAdmin(ctx *context.Context, instanceID string) (*ka
 		return nil, nil, fmt.Errorf("%w", err)
 	}
 
+	if err != nil {
+		return nil, nil, utils.GetAPIError(resp, err)
+	}
+
 	kafkaStatus := kafkaInstance.GetStatus()
 
 	switch kafkaStatus {
-	case StatusProvisioning, StatusAccepted:
+	case StatusAccepted, StatusPreparing, StatusProvisioning:
+		// the instance becomes ready on its own, so the caller can try again later
 		err = fmt.Errorf(`Kafka instance "%v" is not ready yet`, kafkaInstance.GetName())
-		return nil, nil, err
+		return nil, nil, utils.Transient(err)
 	case StatusFailed:
 		err = fmt.Errorf(`Kafka instance "%v" has failed`, kafkaInstance.GetName())
 		return nil, nil, err
@@ -82,6 +100,9 @@ func (c *DefaultClient) KafkaAdmin(ctx *context.Context, instanceID string) (*ka
 	}
 
 	apiURL := kafkaInstance.GetAdminApiServerUrl()
+	if c.kafkaAdminURL != "" {
+		apiURL = c.kafkaAdminURL
+	}
 
 	client := kafkainstance.NewAPIClient(&kafkainstance.Config{
 		BaseURL:    apiURL,
@@ -94,3 +115,52 @@ func (c *DefaultClient) KafkaAdmin(ctx *context.Context, instanceID string) (*ka
 func (c *DefaultClient) HTTPClient() *http.Client {
 	return c.httpClient
 }
+
+func (c *DefaultClient) KafkaDeletionProtectionPatterns() []string {
+	return c.kafkaDeletionProtectionPatterns
+}
+
+func (c *DefaultClient) WaitConfig() utils.WaitConfig {
+	return c.waitConfig
+}
+
+func (c *DefaultClient) Username() (string, error) {
+	transport, ok := c.httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return "", fmt.Errorf("the http client does not authenticate with an access token")
+	}
+
+	token, err := transport.Source.Token()
+	if err != nil {
+		return "", fmt.Errorf("unable to get an access token: %w", err)
+	}
+
+	return usernameFromAccessToken(token.AccessToken)
+}
+
+// usernameFromAccessToken reads the username from the claims of the access token. The signature is not checked as
+// the token was just issued to the provider.
+func usernameFromAccessToken(accessToken string) (string, error) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("the access token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("unable to decode the claims of the access token: %w", err)
+	}
+
+	var claims struct {
+		PreferredUsername string `json:"preferred_username"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("unable to parse the claims of the access token: %w", err)
+	}
+
+	if claims.PreferredUsername == "" {
+		return "", fmt.Errorf("the access token does not contain a username")
+	}
+
+	return claims.PreferredUsername, nil
+}
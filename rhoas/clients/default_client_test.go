@@ -0,0 +1,143 @@
+package clients_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	"github.com/stretchr/testify/assert"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/clients"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+func TestUsernameFromAccessToken(t *testing.T) {
+	token := func(claims string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+
+	t.Run("user", func(t *testing.T) {
+		username, err := clients.UsernameFromAccessToken(token(`{"preferred_username":"alice","org_id":"1234"}`))
+		assert.NoError(t, err, "got unexpected error while reading the username")
+		assert.Equal(t, "alice", username, "unexpected username")
+	})
+
+	t.Run("no username", func(t *testing.T) {
+		_, err := clients.UsernameFromAccessToken(token(`{"org_id":"1234"}`))
+		assert.Error(t, err, "expected an error when the token has no username")
+	})
+
+	t.Run("not a JWT", func(t *testing.T) {
+		_, err := clients.UsernameFromAccessToken("opaque")
+		assert.Error(t, err, "expected an error when the token is not a JWT")
+	})
+}
+
+// newKafkaServer returns a Kafka management API server that answers with the given status codes and Kafka instance
+// statuses, one per call, repeating the last one
+func newKafkaServer(t *testing.T, statusCodes []int, statuses []string) *httptest.Server {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := calls
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCodes[i])
+		fmt.Fprintf(w, `{"id":"c8jd7k0o6j2l0j6b0k5g","kind":"Kafka","name":"prices","status":%q,"bootstrap_server_host":"prices.kafka:443"}`, statuses[i])
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newDefaultClient(server *httptest.Server) *clients.DefaultClient {
+	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	return clients.NewDefaultClient(kafkaClient, nil, server.Client(), "", nil, utils.WaitConfig{
+		PollInterval:      time.Millisecond,
+		MaxPollInterval:   time.Millisecond,
+		BackoffMultiplier: 1,
+	})
+}
+
+func TestKafkaAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		status     string
+		wantErr    bool
+		transient  bool
+	}{
+		{name: "ready", statusCode: http.StatusOK, status: "ready"},
+		{name: "accepted", statusCode: http.StatusOK, status: "accepted", wantErr: true, transient: true},
+		{name: "preparing", statusCode: http.StatusOK, status: "preparing", wantErr: true, transient: true},
+		{name: "provisioning", statusCode: http.StatusOK, status: "provisioning", wantErr: true, transient: true},
+		{name: "failed", statusCode: http.StatusOK, status: "failed", wantErr: true},
+		{name: "deleting", statusCode: http.StatusOK, status: "deleting", wantErr: true},
+		{name: "service unavailable", statusCode: http.StatusServiceUnavailable, status: "ready", wantErr: true, transient: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := newDefaultClient(newKafkaServer(t, []int{tt.statusCode}, []string{tt.status}))
+
+			ctx := context.Background()
+			instanceAPI, kafka, err := client.KafkaAdmin(&ctx, "c8jd7k0o6j2l0j6b0k5g")
+			if !tt.wantErr {
+				assert.NoError(t, err, "got unexpected error while getting the admin API client")
+				assert.NotNil(t, instanceAPI, "expected an admin API client")
+				assert.Equal(t, "prices", kafka.GetName(), "unexpected Kafka instance")
+				return
+			}
+
+			assert.Error(t, err, "expected an error when the Kafka instance is %s", tt.status)
+			assert.Equal(t, tt.transient, utils.IsTransient(err), "unexpected transient error")
+		})
+	}
+}
+
+func TestRetryKafkaAdmin(t *testing.T) {
+	t.Run("instance becomes ready", func(t *testing.T) {
+		client := newDefaultClient(newKafkaServer(t,
+			[]int{http.StatusOK, http.StatusServiceUnavailable, http.StatusOK},
+			[]string{"provisioning", "ready", "ready"}))
+
+		calls := 0
+		err := rhoasAPI.RetryKafkaAdmin(context.Background(), client, "c8jd7k0o6j2l0j6b0k5g", func(instanceAPI *kafkainstanceclient.APIClient) error {
+			calls++
+			if calls == 1 {
+				return utils.Transient(fmt.Errorf("too many requests"))
+			}
+			return nil
+		})
+		assert.NoError(t, err, "got unexpected error while retrying")
+		assert.Equal(t, 2, calls, "expected the admin API call to be retried once the instance is ready")
+	})
+
+	t.Run("instance never becomes ready", func(t *testing.T) {
+		client := newDefaultClient(newKafkaServer(t, []int{http.StatusOK}, []string{"preparing"}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := rhoasAPI.RetryKafkaAdmin(ctx, client, "c8jd7k0o6j2l0j6b0k5g", func(instanceAPI *kafkainstanceclient.APIClient) error {
+			t.Error("the admin API must not be called before the instance is ready")
+			return nil
+		})
+		assert.Error(t, err, "expected an error when the instance never becomes ready")
+		assert.Contains(t, err.Error(), `Kafka instance "prices" is not ready yet`, "expected the error of the attempts to be listed")
+	})
+}
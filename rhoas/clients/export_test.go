@@ -0,0 +1,6 @@
+package clients
+
+// expose the unexported functions of the package to the tests in clients_test
+var (
+	UsernameFromAccessToken = usernameFromAccessToken
+)
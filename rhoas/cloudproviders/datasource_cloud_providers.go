@@ -2,16 +2,10 @@ package cloudproviders
 
 import (
 	"context"
-	"io"
-	"log"
-	"strconv"
-	"time"
-
-	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
 )
 
 func DataSourceCloudProviders() *schema.Resource {
@@ -19,6 +13,12 @@ func DataSourceCloudProviders() *schema.Resource {
 		Description: "`rhoas_cloud_providers` provides a list of the cloud providers available for Red Hat OpenShift Streams for Apache Kafka.",
 		ReadContext: dataSourceCloudProvidersRead,
 		Schema: map[string]*schema.Schema{
+			"enabled_only": {
+				Description: "Only list the cloud providers that are enabled",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"cloud_providers": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -55,31 +55,32 @@ func dataSourceCloudProvidersRead(ctx context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 
-	c, ok := m.(*kafkamgmtclient.APIClient)
+	api, ok := m.(rhoasAPI.Clients)
 	if !ok {
-		return diag.Errorf("unable to cast %v to *connection.KeycloakConnection", m)
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
 	}
 
-	data, resp, err := c.DefaultApi.GetCloudProviders(ctx).Execute()
-	if err != nil {
-		bodyBytes, ioErr := io.ReadAll(resp.Body)
-		if ioErr != nil {
-			log.Fatal(ioErr)
-		}
-		return diag.Errorf("%s%s", err.Error(), string(bodyBytes))
+	val := d.Get("enabled_only")
+	enabledOnly, ok := val.(bool)
+	if !ok {
+		return diag.Errorf("unable to cast %v to bool", val)
 	}
 
-	obj, err := utils.AsMap(data)
+	cloudProviders, err := listCloudProviders(ctx, api.KafkaMgmt())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("cloud_providers", obj["items"]); err != nil {
+	if err := d.Set("cloud_providers", flattenCloudProviders(cloudProviders, enabledOnly)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	// the list of cloud providers does not depend on the arguments other than the filter
+	if enabledOnly {
+		d.SetId("enabled")
+	} else {
+		d.SetId("all")
+	}
 
 	return diags
 }
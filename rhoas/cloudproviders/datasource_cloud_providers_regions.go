@@ -2,26 +2,27 @@ package cloudproviders
 
 import (
 	"context"
-	"io"
-	"log"
-	"strconv"
-	"time"
-
-	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
 )
 
 func DataSourceCloudProviderRegions() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceCloudProviderRegionsRead,
-		Description: "`rhoas_cloud_providers_regions` provides a list of the regions available for Red Hat OpenShift Streams for Apache Kafka.",
+		Description: "`rhoas_cloud_provider_regions` provides a list of the regions available for Red Hat OpenShift Streams for Apache Kafka.",
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Description: "The id of the cloud provider, for example `aws`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"enabled_only": {
+				Description: "Only list the regions that are enabled",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
 			},
 			"regions": {
 				Type:     schema.TypeList,
@@ -45,6 +46,36 @@ func DataSourceCloudProviderRegions() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"supported_instance_types": {
+							Description: "The Kafka instance types supported in the region, for example `standard` or `developer`",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"capacity": {
+							Description: "The capacity left in the region for each supported instance type",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_type": {
+										Description: "The Kafka instance type",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"available_sizes": {
+										Description: "The sizes of the instance type that can be created in the region, taking the current capacity and the regional limits into account. Empty when the region has no capacity left for the instance type.",
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -56,9 +87,9 @@ func dataSourceCloudProviderRegionsRead(ctx context.Context, d *schema.ResourceD
 
 	var diags diag.Diagnostics
 
-	c, ok := m.(*kafkamgmtclient.APIClient)
+	api, ok := m.(rhoasAPI.Clients)
 	if !ok {
-		return diag.Errorf("unable to cast %v to *connection.KeycloakConnection", m)
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
 	}
 
 	val := d.Get("id")
@@ -67,26 +98,23 @@ func dataSourceCloudProviderRegionsRead(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("unable to cast %v to string", val)
 	}
 
-	data, resp, err := c.DefaultApi.GetCloudProviderRegions(ctx, id).Execute()
-	if err != nil {
-		bodyBytes, ioErr := io.ReadAll(resp.Body)
-		if ioErr != nil {
-			log.Fatal(ioErr)
-		}
-		return diag.Errorf("%s%s", err.Error(), string(bodyBytes))
+	val = d.Get("enabled_only")
+	enabledOnly, ok := val.(bool)
+	if !ok {
+		return diag.Errorf("unable to cast %v to bool", val)
 	}
 
-	obj, err := utils.AsMap(data)
+	regions, err := listCloudProviderRegions(ctx, api.KafkaMgmt(), id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("regions", obj["items"]); err != nil {
+	if err := d.Set("regions", flattenCloudRegions(regions, enabledOnly)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	// the id is the cloud provider the regions are listed for
+	d.SetId(id)
 
 	return diags
 }
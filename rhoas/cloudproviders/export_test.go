@@ -0,0 +1,7 @@
+package cloudproviders
+
+// expose the unexported functions of the package to the tests in cloudproviders_test
+var (
+	FlattenCloudProviders = flattenCloudProviders
+	FlattenCloudRegions   = flattenCloudRegions
+)
@@ -0,0 +1,108 @@
+package cloudproviders
+
+import (
+	"context"
+	"strconv"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+// pageSize is the number of cloud providers or regions requested per page when listing them
+const pageSize = 100
+
+// listCloudProviders returns every cloud provider, going through all the pages of the list
+func listCloudProviders(ctx context.Context, api kafkamgmtclient.DefaultApi) ([]kafkamgmtclient.CloudProvider, error) {
+	var cloudProviders []kafkamgmtclient.CloudProvider
+
+	for page := 1; ; page++ {
+		list, resp, err := api.GetCloudProviders(ctx).
+			Page(strconv.Itoa(page)).
+			Size(strconv.Itoa(pageSize)).
+			Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+
+		items := list.GetItems()
+		cloudProviders = append(cloudProviders, items...)
+
+		if len(items) == 0 || int32(page*pageSize) >= list.GetTotal() {
+			return cloudProviders, nil
+		}
+	}
+}
+
+// listCloudProviderRegions returns every region of the cloud provider, going through all the pages of the list
+func listCloudProviderRegions(ctx context.Context, api kafkamgmtclient.DefaultApi, cloudProviderID string) ([]kafkamgmtclient.CloudRegion, error) {
+	var regions []kafkamgmtclient.CloudRegion
+
+	for page := 1; ; page++ {
+		list, resp, err := api.GetCloudProviderRegions(ctx, cloudProviderID).
+			Page(strconv.Itoa(page)).
+			Size(strconv.Itoa(pageSize)).
+			Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+
+		items := list.GetItems()
+		regions = append(regions, items...)
+
+		if len(items) == 0 || int32(page*pageSize) >= list.GetTotal() {
+			return regions, nil
+		}
+	}
+}
+
+func flattenCloudProviders(cloudProviders []kafkamgmtclient.CloudProvider, enabledOnly bool) []interface{} {
+	flattened := make([]interface{}, 0, len(cloudProviders))
+	for i := range cloudProviders {
+		if enabledOnly && !cloudProviders[i].GetEnabled() {
+			continue
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"display_name": cloudProviders[i].GetDisplayName(),
+			"enabled":      cloudProviders[i].GetEnabled(),
+			"id":           cloudProviders[i].GetId(),
+			"kind":         cloudProviders[i].GetKind(),
+			"name":         cloudProviders[i].GetName(),
+		})
+	}
+	return flattened
+}
+
+func flattenCloudRegions(regions []kafkamgmtclient.CloudRegion, enabledOnly bool) []interface{} {
+	flattened := make([]interface{}, 0, len(regions))
+	for i := range regions {
+		if enabledOnly && !regions[i].GetEnabled() {
+			continue
+		}
+
+		capacity := regions[i].GetCapacity()
+		instanceTypes := make([]interface{}, 0, len(capacity))
+		flattenedCapacity := make([]interface{}, 0, len(capacity))
+		for _, item := range capacity {
+			instanceTypes = append(instanceTypes, item.GetInstanceType())
+			flattenedCapacity = append(flattenedCapacity, map[string]interface{}{
+				"instance_type":   item.GetInstanceType(),
+				"available_sizes": item.GetAvailableSizes(),
+			})
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"display_name":             regions[i].GetDisplayName(),
+			"enabled":                  regions[i].GetEnabled(),
+			"id":                       regions[i].GetId(),
+			"kind":                     regions[i].GetKind(),
+			"supported_instance_types": instanceTypes,
+			"capacity":                 flattenedCapacity,
+		})
+	}
+	return flattened
+}
@@ -0,0 +1,48 @@
+package cloudproviders_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/cloudproviders"
+)
+
+func TestFlattenCloudProviders(t *testing.T) {
+	aws := kafkamgmtclient.NewCloudProvider(true)
+	aws.SetId("aws")
+	gcp := kafkamgmtclient.NewCloudProvider(false)
+	gcp.SetId("gcp")
+	list := []kafkamgmtclient.CloudProvider{*aws, *gcp}
+
+	assert.Len(t, cloudproviders.FlattenCloudProviders(list, false), 2, "expected every cloud provider to be listed")
+
+	enabled := cloudproviders.FlattenCloudProviders(list, true)
+	assert.Len(t, enabled, 1, "expected only the enabled cloud providers to be listed")
+	assert.Equal(t, "aws", enabled[0].(map[string]interface{})["id"], "unexpected cloud provider") //nolint:forcetypeassert
+}
+
+func TestFlattenCloudRegions(t *testing.T) {
+	east := kafkamgmtclient.NewCloudRegion(true, []kafkamgmtclient.RegionCapacityListItem{
+		*kafkamgmtclient.NewRegionCapacityListItem("standard", []string{"x1", "x2"}),
+		*kafkamgmtclient.NewRegionCapacityListItem("developer", []string{}),
+	})
+	east.SetId("us-east-1")
+	west := kafkamgmtclient.NewCloudRegion(false, []kafkamgmtclient.RegionCapacityListItem{})
+	west.SetId("us-west-1")
+	list := []kafkamgmtclient.CloudRegion{*east, *west}
+
+	assert.Len(t, cloudproviders.FlattenCloudRegions(list, false), 2, "expected every region to be listed")
+
+	resource := cloudproviders.DataSourceCloudProviderRegions()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"id": "aws"})
+
+	err := d.Set("regions", cloudproviders.FlattenCloudRegions(list, true))
+	assert.NoError(t, err, "the flattened regions do not match the schema")
+	assert.Equal(t, 1, d.Get("regions.#"), "expected only the enabled regions to be listed")
+	assert.Equal(t, "us-east-1", d.Get("regions.0.id"), "unexpected region")
+	assert.Equal(t, []interface{}{"standard", "developer"}, d.Get("regions.0.supported_instance_types"), "unexpected supported instance types")
+	assert.Equal(t, []interface{}{"x1", "x2"}, d.Get("regions.0.capacity.0.available_sizes"), "unexpected available sizes")
+	assert.Equal(t, 0, d.Get("regions.0.capacity.1.available_sizes.#"), "expected no capacity left for the developer instance type")
+}
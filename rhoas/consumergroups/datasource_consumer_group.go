@@ -0,0 +1,100 @@
+package consumergroups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+func DataSourceConsumerGroup() *schema.Resource {
+	groupSchema := consumerGroupSchema()
+
+	groupSchema["kafka_id"] = &schema.Schema{
+		Description: "The unique ID of the kafka instance",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	groupSchema["group_id"] = &schema.Schema{
+		Description: "The unique id of the consumer group",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	groupSchema["topic"] = &schema.Schema{
+		Description: "Only include the partitions of this topic",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	return &schema.Resource{
+		Description: "`rhoas_consumer_group` provides a consumer group of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, including its offsets and lag.",
+		ReadContext: dataSourceConsumerGroupRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: groupSchema,
+	}
+}
+
+func dataSourceConsumerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	groupID, ok := d.Get("group_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the group id value in the schema resource")
+	}
+
+	topic, ok := d.Get("topic").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the topic value in the schema resource")
+	}
+
+	var group kafkainstanceclient.ConsumerGroup
+
+	err := rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		request := instanceAPI.GroupsApi.GetConsumerGroupById(ctx, groupID)
+		if topic != "" {
+			request = request.Topic(topic)
+		}
+
+		var resp *http.Response
+		var err error
+		group, resp, err = request.Execute()
+		if err != nil {
+			return utils.GetAPIError(resp, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for key, value := range flattenConsumerGroup(&group, topic) {
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", kafkaID, groupID))
+
+	return diags
+}
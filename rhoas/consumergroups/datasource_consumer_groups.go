@@ -0,0 +1,96 @@
+package consumergroups
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+)
+
+func DataSourceConsumerGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_consumer_groups` provides a list of the consumer groups of a Kafka instance in Red Hat OpenShift Streams for Apache Kafka, including their offsets and lag.",
+		ReadContext: dataSourceConsumerGroupsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"kafka_id": {
+				Description: "The unique ID of the kafka instance",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"group_id_prefix": {
+				Description: "Only list the consumer groups whose id starts with this prefix",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"topic": {
+				Description: "Only list the consumer groups that consume from this topic, and only include the partitions of this topic",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"consumer_groups": {
+				Description: "The list of consumer groups",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: consumerGroupSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConsumerGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	groupIDPrefix, ok := d.Get("group_id_prefix").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the group id prefix value in the schema resource")
+	}
+
+	topic, ok := d.Get("topic").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the topic value in the schema resource")
+	}
+
+	var groups []kafkainstanceclient.ConsumerGroup
+
+	err := rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		var err error
+		groups, err = listConsumerGroups(ctx, instanceAPI, groupIDPrefix, topic)
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	raw := make([]interface{}, 0, len(groups))
+	for i := range groups {
+		raw = append(raw, flattenConsumerGroup(&groups[i], topic))
+	}
+
+	if err = d.Set("consumer_groups", raw); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", kafkaID, groupIDPrefix, topic))
+
+	return diags
+}
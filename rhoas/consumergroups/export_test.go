@@ -0,0 +1,7 @@
+package consumergroups
+
+// expose the unexported functions of the package to the tests in consumergroups_test
+var (
+	FlattenConsumerGroup                   = flattenConsumerGroup
+	MapResourceDataToResetOffsetParameters = mapResourceDataToResetOffsetParameters
+)
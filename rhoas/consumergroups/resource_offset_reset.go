@@ -0,0 +1,301 @@
+package consumergroups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+func ResourceConsumerGroupOffsetReset() *schema.Resource {
+	return &schema.Resource{
+		Description:   "`rhoas_consumer_group_offset_reset` resets the offsets of a consumer group in a Kafka instance in Red Hat OpenShift Streams for Apache Kafka. The offsets are reset when the resource is created and again whenever any of its arguments, including `triggers`, change. The consumer group must not have any active consumers while its offsets are reset. Destroying the resource does not change the offsets.",
+		CreateContext: offsetResetCreate,
+		ReadContext:   offsetResetRead,
+		DeleteContext: offsetResetDelete,
+		CustomizeDiff: offsetResetCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"kafka_id": {
+				Description: "The unique ID of the kafka instance the consumer group belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The unique id of the consumer group",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"offset": {
+				Description: "Where the offsets are reset to. One of `earliest`, `latest`, `absolute` or `timestamp`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(kafkainstanceclient.OFFSETTYPE_EARLIEST),
+					string(kafkainstanceclient.OFFSETTYPE_LATEST),
+					string(kafkainstanceclient.OFFSETTYPE_ABSOLUTE),
+					string(kafkainstanceclient.OFFSETTYPE_TIMESTAMP),
+				}, false)),
+			},
+			"value": {
+				Description: "The offset to reset to when `offset` is `absolute`, or the RFC3339 date and time to reset to when `offset` is `timestamp`. Must not be set for `earliest` and `latest`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"topic": {
+				Description: "The topics to reset the offsets of. When no topic is set, the offsets of every topic the consumer group consumes are reset.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the topic",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"partitions": {
+							Description: "The partitions of the topic to reset the offsets of. When no partition is set, the offsets of every partition are reset.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntAtLeast(0),
+							},
+						},
+					},
+				},
+			},
+			"triggers": {
+				Description: "Arbitrary values that reset the offsets again whenever they change",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"offsets": {
+				Description: "The offsets of the consumer group after the reset",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"topic": {
+							Description: "The name of the topic",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"partition": {
+							Description: "The partition number",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"offset": {
+							Description: "The offset of the consumer group in the partition",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func offsetResetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the offsets cannot be restored, so destroying the resource only removes it from the state
+	d.SetId("")
+	return nil
+}
+
+func offsetResetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the reset is an action rather than an object in the kafka instance, so there is nothing to read back
+	return nil
+}
+
+func offsetResetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the kafka id value in the schema resource")
+	}
+
+	groupID, ok := d.Get("group_id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the group id value in the schema resource")
+	}
+
+	parameters, err := mapResourceDataToResetOffsetParameters(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var result kafkainstanceclient.ConsumerGroupResetOffsetResult
+
+	err = rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		var resp *http.Response
+		var err error
+		result, resp, err = instanceAPI.GroupsApi.ResetConsumerGroupOffset(ctx, groupID).
+			ConsumerGroupResetOffsetParameters(*parameters).
+			Execute()
+		if err != nil {
+			return utils.GetAPIError(resp, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", kafkaID, groupID))
+
+	if err = d.Set("offsets", flattenResetOffsetResult(&result)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func offsetResetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("offset") || !d.NewValueKnown("value") {
+		return nil
+	}
+
+	offset, ok := d.Get("offset").(string)
+	if !ok {
+		return errors.Errorf("There was a problem getting the offset value in the schema resource")
+	}
+
+	value, ok := d.Get("value").(string)
+	if !ok {
+		return errors.Errorf("There was a problem getting the reset value in the schema resource")
+	}
+
+	return validateResetOffsetValue(offset, value)
+}
+
+// validateResetOffsetValue checks that the value matches the type of the offset reset
+func validateResetOffsetValue(offset string, value string) error {
+	switch kafkainstanceclient.OffsetType(offset) {
+	case kafkainstanceclient.OFFSETTYPE_ABSOLUTE:
+		if parsed, err := strconv.ParseInt(value, 10, 64); err != nil || parsed < 0 {
+			return errors.Errorf("value must be a non negative offset when offset is %s, got %q", offset, value)
+		}
+	case kafkainstanceclient.OFFSETTYPE_TIMESTAMP:
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return errors.Errorf("value must be an RFC3339 date and time when offset is %s, got %q", offset, value)
+		}
+	default:
+		if value != "" {
+			return errors.Errorf("value must not be set when offset is %s", offset)
+		}
+	}
+
+	return nil
+}
+
+func mapResourceDataToResetOffsetParameters(d *schema.ResourceData) (*kafkainstanceclient.ConsumerGroupResetOffsetParameters, error) {
+	offset, ok := d.Get("offset").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the offset value in the schema resource")
+	}
+
+	value, ok := d.Get("value").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the reset value in the schema resource")
+	}
+
+	if err := validateResetOffsetValue(offset, value); err != nil {
+		return nil, err
+	}
+
+	offsetType, err := kafkainstanceclient.NewOffsetTypeFromValue(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := kafkainstanceclient.NewConsumerGroupResetOffsetParameters(*offsetType)
+	if value != "" {
+		parameters.SetValue(value)
+	}
+
+	rawTopics, ok := d.Get("topic").([]interface{})
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the topic value in the schema resource")
+	}
+
+	if len(rawTopics) == 0 {
+		return parameters, nil
+	}
+
+	topics := make([]kafkainstanceclient.TopicsToResetOffset, 0, len(rawTopics))
+	for _, rawTopic := range rawTopics {
+		values, ok := rawTopic.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the topic value in the schema resource")
+		}
+
+		name, ok := values["name"].(string)
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the topic name value in the schema resource")
+		}
+
+		topic := kafkainstanceclient.NewTopicsToResetOffset(name)
+
+		rawPartitions, ok := values["partitions"].([]interface{})
+		if ok && len(rawPartitions) > 0 {
+			partitions := make([]int32, 0, len(rawPartitions))
+			for _, rawPartition := range rawPartitions {
+				partition, ok := rawPartition.(int)
+				if !ok {
+					return nil, errors.Errorf("There was a problem getting the partitions value in the schema resource")
+				}
+				partitions = append(partitions, int32(partition))
+			}
+			topic.SetPartitions(partitions)
+		}
+
+		topics = append(topics, *topic)
+	}
+	parameters.SetTopics(topics)
+
+	return parameters, nil
+}
+
+func flattenResetOffsetResult(result *kafkainstanceclient.ConsumerGroupResetOffsetResult) []interface{} {
+	items := result.GetItems()
+	offsets := make([]interface{}, 0, len(items))
+	for i := range items {
+		offsets = append(offsets, map[string]interface{}{
+			"topic":     items[i].GetTopic(),
+			"partition": int(items[i].GetPartition()),
+			"offset":    int(items[i].GetOffset()),
+		})
+	}
+	return offsets
+}
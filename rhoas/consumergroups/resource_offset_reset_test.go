@@ -0,0 +1,127 @@
+package consumergroups_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/consumergroups"
+)
+
+func TestOffsetResetCustomizeDiff(t *testing.T) {
+	resource := consumergroups.ResourceConsumerGroupOffsetReset()
+
+	tests := []struct {
+		name    string
+		offset  string
+		value   string
+		wantErr bool
+	}{
+		{name: "earliest", offset: "earliest"},
+		{name: "earliest with value", offset: "earliest", value: "10", wantErr: true},
+		{name: "absolute", offset: "absolute", value: "10"},
+		{name: "absolute without value", offset: "absolute", wantErr: true},
+		{name: "absolute with negative value", offset: "absolute", value: "-1", wantErr: true},
+		{name: "timestamp", offset: "timestamp", value: "2022-06-01T10:00:00Z"},
+		{name: "timestamp with invalid value", offset: "timestamp", value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"kafka_id": "c8jd7k0o6j2l0j6b0k5g",
+				"group_id": "billing",
+				"offset":   tt.offset,
+			}
+			if tt.value != "" {
+				config["value"] = tt.value
+			}
+
+			_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if tt.wantErr {
+				assert.Error(t, err, "expected an error for an invalid offset value")
+			} else {
+				assert.NoError(t, err, "got unexpected error for a valid offset value")
+			}
+		})
+	}
+}
+
+func TestOffsetResetTriggers(t *testing.T) {
+	resource := consumergroups.ResourceConsumerGroupOffsetReset()
+	state := &terraform.InstanceState{
+		ID: "c8jd7k0o6j2l0j6b0k5g/billing",
+		Attributes: map[string]string{
+			"id":               "c8jd7k0o6j2l0j6b0k5g/billing",
+			"kafka_id":         "c8jd7k0o6j2l0j6b0k5g",
+			"group_id":         "billing",
+			"offset":           "earliest",
+			"triggers.%":       "1",
+			"triggers.release": "1",
+			"offsets.#":        "0",
+		},
+	}
+
+	config := func(release string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"kafka_id": "c8jd7k0o6j2l0j6b0k5g",
+			"group_id": "billing",
+			"offset":   "earliest",
+			"triggers": map[string]interface{}{"release": release},
+		})
+	}
+
+	diff, err := resource.Diff(context.Background(), state, config("1"), nil)
+	assert.NoError(t, err, "got unexpected error while keeping the triggers")
+	assert.True(t, diff == nil || diff.Empty(), "expected no changes")
+
+	diff, err = resource.Diff(context.Background(), state, config("2"), nil)
+	assert.NoError(t, err, "got unexpected error while changing the triggers")
+	assert.True(t, diff.RequiresNew(), "changing the triggers should reset the offsets again")
+}
+
+func TestMapResourceDataToResetOffsetParameters(t *testing.T) {
+	resource := consumergroups.ResourceConsumerGroupOffsetReset()
+
+	t.Run("all topics", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"kafka_id": "c8jd7k0o6j2l0j6b0k5g",
+			"group_id": "billing",
+			"offset":   "latest",
+		})
+
+		parameters, err := consumergroups.MapResourceDataToResetOffsetParameters(d)
+		assert.NoError(t, err, "got unexpected error while mapping the reset parameters")
+		assert.Equal(t, kafkainstanceclient.OFFSETTYPE_LATEST, parameters.GetOffset(), "unexpected offset type")
+		assert.False(t, parameters.HasValue(), "no value should be sent for latest")
+		assert.False(t, parameters.HasTopics(), "no topics should be sent when none are set")
+	})
+
+	t.Run("selected partitions", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"kafka_id": "c8jd7k0o6j2l0j6b0k5g",
+			"group_id": "billing",
+			"offset":   "absolute",
+			"value":    "42",
+			"topic": []interface{}{
+				map[string]interface{}{"name": "prices", "partitions": []interface{}{0, 2}},
+				map[string]interface{}{"name": "orders"},
+			},
+		})
+
+		parameters, err := consumergroups.MapResourceDataToResetOffsetParameters(d)
+		assert.NoError(t, err, "got unexpected error while mapping the reset parameters")
+		assert.Equal(t, "42", parameters.GetValue(), "unexpected value")
+
+		topics := parameters.GetTopics()
+		assert.Len(t, topics, 2, "expected both topics to be sent")
+		assert.Equal(t, "prices", topics[0].GetTopic(), "unexpected topic")
+		assert.Equal(t, []int32{0, 2}, topics[0].GetPartitions(), "unexpected partitions")
+		assert.Equal(t, "orders", topics[1].GetTopic(), "unexpected topic")
+		assert.False(t, topics[1].HasPartitions(), "no partitions should be sent when none are set")
+	})
+}
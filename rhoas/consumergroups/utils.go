@@ -0,0 +1,183 @@
+package consumergroups
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+const consumerGroupPageSize int32 = 100
+
+// consumerGroupSchema returns the attributes that describe a consumer group. The attributes are computed, and the
+// data sources add their own arguments on top of them
+func consumerGroupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"group_id": {
+			Description: "The unique id of the consumer group",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"state": {
+			Description: "The state of the consumer group, for example `STABLE` or `EMPTY`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"total_lag": {
+			Description: "The sum of the lag of every partition consumed by the consumer group",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"active_consumers": {
+			Description: "The number of active consumers in the consumer group",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"lagging_partitions": {
+			Description: "The number of partitions with a lag",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"unassigned_partitions": {
+			Description: "The number of partitions that are not assigned to a consumer",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"members": {
+			Description: "The member ids of the consumers in the consumer group",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"partitions": {
+			Description: "The offsets of the consumer group for every partition it consumes",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"topic": {
+						Description: "The name of the topic",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"partition": {
+						Description: "The partition number",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"member_id": {
+						Description: "The member id of the consumer the partition is assigned to, empty when the partition is not assigned",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"offset": {
+						Description: "The committed offset of the consumer group in the partition",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"log_end_offset": {
+						Description: "The offset of the last message written to the partition",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"lag": {
+						Description: "The difference between the log end offset and the committed offset",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenConsumerGroup converts a consumer group into the attributes of consumerGroupSchema. When topic is set, only
+// the partitions of that topic are included.
+func flattenConsumerGroup(group *kafkainstanceclient.ConsumerGroup, topic string) map[string]interface{} {
+	var totalLag int64
+	members := []string{}
+	seen := map[string]bool{}
+	partitions := make([]interface{}, 0, len(group.GetConsumers()))
+
+	for _, consumer := range group.GetConsumers() {
+		if topic != "" && consumer.GetTopic() != topic {
+			continue
+		}
+
+		totalLag += consumer.GetLag()
+
+		memberID := consumer.GetMemberId()
+		if memberID != "" && !seen[memberID] {
+			seen[memberID] = true
+			members = append(members, memberID)
+		}
+
+		partitions = append(partitions, map[string]interface{}{
+			"topic":          consumer.GetTopic(),
+			"partition":      int(consumer.GetPartition()),
+			"member_id":      memberID,
+			"offset":         int(consumer.GetOffset()),
+			"log_end_offset": int(consumer.GetLogEndOffset()),
+			"lag":            int(consumer.GetLag()),
+		})
+	}
+
+	sort.Strings(members)
+
+	metrics := group.GetMetrics()
+
+	return map[string]interface{}{
+		"group_id":              group.GetGroupId(),
+		"state":                 string(group.GetState()),
+		"total_lag":             int(totalLag),
+		"active_consumers":      int(metrics.GetActiveConsumers()),
+		"lagging_partitions":    int(metrics.GetLaggingPartitions()),
+		"unassigned_partitions": int(metrics.GetUnassignedPartitions()),
+		"members":               members,
+		"partitions":            partitions,
+	}
+}
+
+// listConsumerGroups returns every consumer group of the kafka instance whose id starts with groupIDPrefix and that
+// consumes from topic, going through all the pages of the list
+func listConsumerGroups(ctx context.Context, instanceAPI *kafkainstanceclient.APIClient, groupIDPrefix string, topic string) ([]kafkainstanceclient.ConsumerGroup, error) {
+	var groups []kafkainstanceclient.ConsumerGroup
+
+	for page := int32(1); ; page++ {
+		request := instanceAPI.GroupsApi.GetConsumerGroups(ctx).
+			Page(page).
+			Size(consumerGroupPageSize)
+
+		// the API filters on group ids containing the value, so the prefix is checked again below
+		if groupIDPrefix != "" {
+			request = request.GroupIdFilter(groupIDPrefix)
+		}
+
+		if topic != "" {
+			request = request.Topic(topic)
+		}
+
+		list, resp, err := request.Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+
+		items := list.GetItems()
+		for i := range items {
+			if strings.HasPrefix(items[i].GetGroupId(), groupIDPrefix) {
+				groups = append(groups, items[i])
+			}
+		}
+
+		if len(items) == 0 || page*consumerGroupPageSize >= list.GetTotal() {
+			return groups, nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package consumergroups_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/consumergroups"
+)
+
+func testConsumerGroup() *kafkainstanceclient.ConsumerGroup {
+	consumer := func(topic string, partition int32, offset int64, logEndOffset int64, memberID string) kafkainstanceclient.Consumer {
+		c := kafkainstanceclient.NewConsumer("billing", topic, partition, offset, logEndOffset-offset)
+		c.SetLogEndOffset(logEndOffset)
+		if memberID != "" {
+			c.SetMemberId(memberID)
+		}
+		return *c
+	}
+
+	group := kafkainstanceclient.NewConsumerGroup("billing", []kafkainstanceclient.Consumer{
+		consumer("prices", 0, 10, 15, "consumer-2"),
+		consumer("prices", 1, 20, 20, "consumer-1"),
+		consumer("orders", 0, 5, 7, "consumer-1"),
+		consumer("orders", 1, 0, 3, ""),
+	})
+	group.SetState(kafkainstanceclient.CONSUMERGROUPSTATE_STABLE)
+	metrics := kafkainstanceclient.NewConsumerGroupMetrics()
+	metrics.SetActiveConsumers(2)
+	metrics.SetLaggingPartitions(3)
+	metrics.SetUnassignedPartitions(1)
+	group.SetMetrics(*metrics)
+
+	return group
+}
+
+func TestFlattenConsumerGroup(t *testing.T) {
+	t.Run("all topics", func(t *testing.T) {
+		flattened := consumergroups.FlattenConsumerGroup(testConsumerGroup(), "")
+		assert.Equal(t, "billing", flattened["group_id"], "unexpected group id")
+		assert.Equal(t, "STABLE", flattened["state"], "unexpected state")
+		assert.Equal(t, 10, flattened["total_lag"], "the total lag should be the sum of the lag of every partition")
+		assert.Equal(t, 2, flattened["active_consumers"], "unexpected active consumers")
+		assert.Equal(t, 1, flattened["unassigned_partitions"], "unexpected unassigned partitions")
+		assert.Equal(t, []string{"consumer-1", "consumer-2"}, flattened["members"], "the members should be unique and sorted")
+		assert.Len(t, flattened["partitions"], 4, "expected every partition to be included")
+	})
+
+	t.Run("single topic", func(t *testing.T) {
+		flattened := consumergroups.FlattenConsumerGroup(testConsumerGroup(), "orders")
+		assert.Equal(t, 5, flattened["total_lag"], "the total lag should only include the partitions of the topic")
+		assert.Equal(t, []string{"consumer-1"}, flattened["members"], "the members should only include the consumers of the topic")
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"topic": "orders", "partition": 0, "member_id": "consumer-1", "offset": 5, "log_end_offset": 7, "lag": 2},
+			map[string]interface{}{"topic": "orders", "partition": 1, "member_id": "", "offset": 0, "log_end_offset": 3, "lag": 3},
+		}, flattened["partitions"], "unexpected partitions")
+	})
+
+	t.Run("matches the schema", func(t *testing.T) {
+		resource := consumergroups.DataSourceConsumerGroups()
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"kafka_id": "c8jd7k0o6j2l0j6b0k5g"})
+
+		err := d.Set("consumer_groups", []interface{}{consumergroups.FlattenConsumerGroup(testConsumerGroup(), "")})
+		assert.NoError(t, err, "the flattened consumer group does not match the schema")
+		assert.Equal(t, 15, d.Get("consumer_groups.0.partitions.0.log_end_offset"), "unexpected log end offset")
+	})
+}
@@ -0,0 +1,203 @@
+package kafkas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+)
+
+func DataSourceKafkaInstanceTypes() *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_kafka_instance_types` provides a list of the Kafka instance types and sizes the organization can create in a region of a cloud provider in Red Hat OpenShift Streams for Apache Kafka.",
+		ReadContext: dataSourceKafkaInstanceTypesRead,
+		Schema: map[string]*schema.Schema{
+			"cloud_provider": {
+				Description: "The cloud provider to list the instance types for. A list of available cloud providers can be obtained using `data.rhoas_cloud_providers`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"region": {
+				Description: "The region to list the instance types for. A list of available regions can be obtained using `data.rhoas_cloud_provider_regions`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"instance_types": {
+				Description: "The list of instance types",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The unique identifier of the instance type, for example `standard` or `developer`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"display_name": {
+							Description: "The human readable name of the instance type",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"available": {
+							Description: "Whether at least one size of the instance type can currently be created in the region",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"sizes": {
+							Description: "The sizes of the instance type",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: instanceSizeSchema(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// instanceSizeSchema returns the attributes that describe a size of a Kafka instance type
+func instanceSizeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Description: "The unique identifier of the size, for example `x1`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"display_name": {
+			Description: "The human readable name of the size",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"available": {
+			Description: "Whether the size can currently be created in the region, taking the current capacity and the regional limits into account",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"maturity_status": {
+			Description: "The maturity level of the size, `stable` or `preview`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ingress_throughput_per_sec": {
+			Description: "The maximum ingress throughput in bytes per second",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"egress_throughput_per_sec": {
+			Description: "The maximum egress throughput in bytes per second",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"max_partitions": {
+			Description: "The maximum number of partitions",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"total_max_connections": {
+			Description: "The maximum number of connections",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"max_connection_attempts_per_sec": {
+			Description: "The maximum number of connection attempts per second",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"max_data_retention_size": {
+			Description: "The maximum storage in bytes",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"max_data_retention_period": {
+			Description: "The maximum data retention period, as an ISO 8601 duration",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"max_message_size": {
+			Description: "The maximum message size in bytes",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"min_in_sync_replicas": {
+			Description: "The minimum number of in-sync replicas",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"replication_factor": {
+			Description: "The replication factor",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"supported_az_modes": {
+			Description: "The availability zone modes the size supports, `single` or `multi`",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"lifespan_seconds": {
+			Description: "The lifespan of an instance of this size in seconds, 0 when the instance does not expire",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"quota_consumed": {
+			Description: "The amount of quota an instance of this size consumes",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"quota_type": {
+			Description: "The type of quota an instance of this size consumes",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"capacity_consumed": {
+			Description: "The amount of data plane cluster capacity an instance of this size consumes",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceKafkaInstanceTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	cloudProvider, ok := d.Get("cloud_provider").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the cloud provider value in the schema resource")
+	}
+
+	region, ok := d.Get("region").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the region value in the schema resource")
+	}
+
+	instanceTypes, err := getInstanceTypes(ctx, api.KafkaMgmt(), cloudProvider, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	availableSizes, err := getAvailableSizes(ctx, api.KafkaMgmt(), cloudProvider, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("instance_types", flattenInstanceTypes(instanceTypes, availableSizes)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", cloudProvider, region))
+
+	return diags
+}
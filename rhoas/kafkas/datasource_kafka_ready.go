@@ -0,0 +1,88 @@
+package kafkas
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+)
+
+func DataSourceKafkaReady() *schema.Resource {
+	return &schema.Resource{
+		Description: "`rhoas_kafka_ready` waits for a Kafka instance in Red Hat OpenShift Streams for Apache Kafka to be ready, for example before creating topics in a Kafka instance managed by another configuration. It fails as soon as the Kafka instance has failed or is being deleted. How long it waits is set by the `read` timeout.",
+		ReadContext: dataSourceKafkaReadyRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The unique identifier of the Kafka instance to wait for",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "The name of the Kafka instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The status of the Kafka instance, always `ready`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"bootstrap_server_host": {
+				Description: "The bootstrap server (host:port)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"admin_api_server_url": {
+				Description: "The URL of the admin API of the Kafka instance, used to manage its topics, ACLs and consumer groups",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKafkaReadyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	id, ok := d.Get("id").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the id value in the schema resource")
+	}
+
+	kafka, err := waitForKafkaReady(ctx, api, id, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "Error waiting for instance (%s) to be ready", id))
+	}
+
+	d.SetId(kafka.GetId())
+
+	if err = d.Set("name", kafka.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("status", kafka.GetStatus()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("bootstrap_server_host", kafka.GetBootstrapServerHost()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("admin_api_server_url", kafka.GetAdminApiServerUrl()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
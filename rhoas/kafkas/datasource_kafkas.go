@@ -2,8 +2,6 @@ package kafkas
 
 import (
 	"context"
-	"io"
-	"log"
 	"strconv"
 	"time"
 
@@ -24,6 +22,32 @@ func DataSourceKafkas() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
+				Deprecated:  "The id argument does not filter the Kafka instances, use search instead.",
+			},
+			"search": {
+				Description: "Only list the Kafka instances matching the search criteria, for example `name like prices%` or `region = us-east-1`. The syntax is similar to the `where` clause of an SQL statement, allowing the `cloud_provider`, `name`, `owner`, `region` and `status` fields, the `<>`, `=` and `LIKE` comparators and the `AND` and `OR` joins.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"order_by": {
+				Description: "The order of the Kafka instances, for example `name asc` or `created_at desc, name`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description: "Only list the Kafka instances with this status, for example `ready`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"owner": {
+				Description: "Only list the Kafka instances owned by this Red Hat account username",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"total": {
+				Description: "The number of Kafka instances listed",
+				Type:        schema.TypeInt,
+				Computed:    true,
 			},
 			"kafkas": {
 				Description: "The list of Kafka instances",
@@ -117,36 +141,44 @@ func dataSourceKafkasRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.Errorf("unable to cast %v to *rhoasClients.Clients", m)
 	}
 
-	var raw []map[string]interface{}
-
-	val := d.Get("id")
-	id, ok := val.(string)
-	if !ok {
-		return diag.Errorf("unable to cast %v to string", val)
+	values := map[string]string{}
+	for _, key := range []string{"id", "search", "order_by", "status", "owner"} {
+		val := d.Get(key)
+		value, ok := val.(string)
+		if !ok {
+			return diag.Errorf("unable to cast %v to string", val)
+		}
+		values[key] = value
 	}
 
-	data, resp, err := api.KafkaMgmt().GetKafkas(ctx).Execute()
+	search, err := buildKafkasSearch(values["search"], values["status"], values["owner"])
 	if err != nil {
-		bodyBytes, ioErr := io.ReadAll(resp.Body)
-		if ioErr != nil {
-			log.Fatal(ioErr)
-		}
-		return diag.Errorf("%s%s", err.Error(), string(bodyBytes))
+		return diag.FromErr(err)
 	}
-	obj, err := utils.AsMap(data)
+
+	kafkas, err := listKafkas(ctx, api.KafkaMgmt(), search, values["order_by"])
 	if err != nil {
-		return diag.FromErr(errors.WithStack(err))
+		return diag.FromErr(err)
 	}
 
-	// coerce the type
-	for _, item := range obj["items"].([]interface{}) {
-		raw = append(raw, item.(map[string]interface{}))
+	raw := make([]map[string]interface{}, 0, len(kafkas))
+	for i := range kafkas {
+		obj, err := utils.AsMap(kafkas[i])
+		if err != nil {
+			return diag.FromErr(errors.WithStack(err))
+		}
+		raw = append(raw, obj)
 	}
 
 	if err := d.Set("kafkas", raw); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("total", len(kafkas)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := values["id"]
 	if id == "" {
 		// use the current timestamp for a list request to force a refresh
 		id = strconv.FormatInt(time.Now().Unix(), 10)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
 	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
 )
@@ -31,8 +32,38 @@ func DataSourceKafka() *schema.Resource {
 			},
 			"name": {
 				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the Kafka instance. It is an error if no or more than one Kafka instance has the name. Exactly one of `id` or `name` must be set.",
+				ExactlyOneOf: []string{
+					"id",
+					"name",
+				},
+			},
+			"plan": {
+				Description: "The plan of the Kafka instance, in the format `<instance_type>.<size>`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"billing_model": {
+				Description: "The billing model of the Kafka instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"marketplace": {
+				Description: "The marketplace the Kafka instance is purchased on",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"billing_cloud_account_id": {
+				Description: "The id of the cloud account used to purchase the Kafka instance on the marketplace",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"reauthentication_enabled": {
+				Description: "Whether clients must reauthenticate their connections to the Kafka instance every 5 minutes",
+				Type:        schema.TypeBool,
 				Computed:    true,
-				Description: "The name of the Kafka instance",
 			},
 			"href": {
 				Type:        schema.TypeString,
@@ -65,9 +96,10 @@ func DataSourceKafka() *schema.Resource {
 				Computed:    true,
 			},
 			"id": {
-				Description: "The unique identifier for the Kafka instance",
+				Description: "The unique identifier for the Kafka instance. Exactly one of `id` or `name` must be set.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"kind": {
 				Type:        schema.TypeString,
@@ -103,14 +135,33 @@ func dataSourceKafkaRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.Errorf("unable to cast %v to string for use as for kafka id", val)
 	}
 
-	kafka, resp, err := api.KafkaMgmt().GetKafkaById(ctx, id).Execute()
-	if err != nil {
-		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
-			return diag.FromErr(apiErr)
+	val = d.Get("name")
+	name, ok := val.(string)
+	if !ok {
+		return diag.Errorf("unable to cast %v to string for use as for kafka name", val)
+	}
+
+	var kafka *kafkamgmtclient.KafkaRequest
+
+	if id != "" {
+		kafkaByID, resp, err := api.KafkaMgmt().GetKafkaById(ctx, id).Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return diag.FromErr(apiErr)
+			}
 		}
+		kafka = &kafkaByID
+	} else {
+		kafkaByName, err := getKafkaByName(ctx, api.KafkaMgmt(), name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		kafka = kafkaByName
 	}
 
-	err = setResourceDataFromKafkaData(d, &kafka)
+	d.SetId(kafka.GetId())
+
+	err := setResourceDataFromKafkaData(d, kafka)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,15 @@
+package kafkas
+
+// expose the unexported functions of the package to the tests in kafkas_test
+var (
+	SelectKafkaByName    = selectKafkaByName
+	BuildKafkasSearch    = buildKafkasSearch
+	FlattenInstanceTypes = flattenInstanceTypes
+	ValidatePlan         = validatePlan
+	KafkaPlan            = kafkaPlan
+	ValidateKafkaToAdopt = validateKafkaToAdopt
+
+	SetResourceDataFromKafkaData        = setResourceDataFromKafkaData
+	MapResourceDataToKafkaUpdateRequest = mapResourceDataToKafkaUpdateRequest
+	CheckKafkaDeletionProtection        = checkKafkaDeletionProtection
+)
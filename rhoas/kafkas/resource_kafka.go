@@ -2,11 +2,14 @@ package kafkas
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"path"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
@@ -18,9 +21,17 @@ func ResourceKafka() *schema.Resource {
 		Description:   "`rhoas_kafka` manages a Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
 		CreateContext: kafkaCreate,
 		ReadContext:   kafkaRead,
+		UpdateContext: kafkaUpdate,
 		DeleteContext: kafkaDelete,
+		CustomizeDiff: kafkaCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"cloud_provider": {
@@ -31,7 +42,7 @@ func ResourceKafka() *schema.Resource {
 				ForceNew:    true,
 			},
 			"region": {
-				Description: "The region to use. A list of available regions can be obtained using `data.rhoas_cloud_providers_regions`.",
+				Description: "The region to use. A list of available regions can be obtained using `data.rhoas_cloud_provider_regions`.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "us-east-1",
@@ -43,6 +54,65 @@ func ResourceKafka() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"plan": {
+				Description:      "The plan of the Kafka instance, in the format `<instance_type>.<size>`, for example `standard.x1`. The instance types and sizes available to the organization can be obtained using `data.rhoas_kafka_instance_types`. When not set, the service picks the default plan.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(planRegexp, "must be in the format <instance_type>.<size>")),
+			},
+			"billing_model": {
+				Description: "The billing model of the Kafka instance, for example `standard` or `marketplace`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"marketplace": {
+				Description: "The marketplace the Kafka instance is purchased on, for example `aws`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"billing_cloud_account_id": {
+				Description: "The id of the cloud account used to purchase the Kafka instance on the marketplace",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"reauthentication_enabled": {
+				Description: "Whether clients must reauthenticate their connections to the Kafka instance every 5 minutes. Defaults to `true`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"adopt_existing": {
+				Description: "Whether to adopt an existing Kafka instance with the same name instead of failing, for example when the creation is retried after a network error. The existing instance must be owned by the user or service account the provider is authenticated as, and be in the same cloud provider and region.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"wait_for_ready": {
+				Description: "Whether to wait for the Kafka instance to be ready when it is created. When `false`, the creation returns as soon as the request is accepted, and the ownership is only transferred to `owner` on the next apply.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"wait_for_deletion": {
+				Description: "Whether to wait for the Kafka instance to be deleted. When `false`, the deletion returns as soon as the request is accepted.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"deletion_protection": {
+				Description: "Whether Terraform is prevented from deleting the Kafka instance. To delete a protected Kafka instance, set it to `false` and apply before destroying the instance. Failed instances are not protected, so that they can be replaced. The provider can also protect Kafka instances by name using `kafka_deletion_protection`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 			"href": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -55,8 +125,9 @@ func ResourceKafka() *schema.Resource {
 			},
 			"owner": {
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
-				Description: "The username of the Red Hat account that owns the Kafka instance",
+				Description: "The username of the Red Hat account that owns the Kafka instance. Changing it transfers the ownership of the Kafka instance to another user of the organization, without recreating it. When set on creation, the ownership is transferred once the Kafka instance is ready. Once transferred, the service account or user Terraform runs as may no longer be allowed to manage the Kafka instance.",
 			},
 			"bootstrap_server_host": {
 				Description: "The bootstrap server (host:port)",
@@ -88,6 +159,11 @@ func ResourceKafka() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"failed_reason": {
+				Description: "The reason the instance failed. A failed instance is replaced on the next apply.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -101,8 +177,12 @@ func kafkaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.Errorf("unable to cast %v to rhoasAPI.Clients)", m)
 	}
 
-	apiErr, _, err := api.KafkaMgmt().DeleteKafkaById(ctx, d.Id()).Async(true).Execute()
-	if err != nil && err.Error() == "404 " {
+	if protectionDiags := checkKafkaDeletionProtection(d, api.KafkaDeletionProtectionPatterns()); protectionDiags.HasError() {
+		return protectionDiags
+	}
+
+	apiErr, resp, err := api.KafkaMgmt().DeleteKafkaById(ctx, d.Id()).Async(true).Execute()
+	if utils.IsNotFound(resp, err) {
 		// the resource is deleted already
 		d.SetId("")
 		return diags
@@ -114,32 +194,37 @@ func kafkaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.Errorf("%s", err.Error())
 	}
 
-	deleteStateConf := &resource.StateChangeConf{
-		Delay: 5 * time.Second,
-		Pending: []string{
-			"deprovision", "deleting",
-		},
-		Refresh: func() (interface{}, string, error) {
+	waitForDeletion, ok := d.Get("wait_for_deletion").(bool)
+	if !ok {
+		return diag.Errorf("There was a problem getting the wait for deletion value in the schema resource")
+	}
+
+	if !waitForDeletion {
+		d.SetId("")
+		return diags
+	}
+
+	deleteStateConf := api.WaitConfig().StateChangeConf(
+		[]string{kafkaStatusDeprovision, kafkaStatusDeleting},
+		[]string{"deleted", "404"},
+		func() (interface{}, string, error) {
 			data, resp, err1 := api.KafkaMgmt().GetKafkaById(ctx, d.Id()).Execute()
+			if utils.IsNotFound(resp, err1) {
+				return data, "404", nil
+			}
 			if err1 != nil {
-				if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				if apiErr := utils.GetAPIError(resp, err1); apiErr != nil {
 					return nil, "", apiErr
 				}
 			}
-			return data, *data.Status, nil
-		},
-		Target: []string{
-			"deleted", "404",
+			return data, data.GetStatus(), nil
 		},
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
-		MinTimeout:                5 * time.Second,
-		NotFoundChecks:            0,
-		ContinuousTargetOccurence: 0,
-	}
+		d.Timeout(schema.TimeoutDelete),
+	)
 
 	_, err = deleteStateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return diag.FromErr(errors.Wrapf(err, "Error waiting for example instance (%s) to be deleted", d.Id()))
+		return diag.FromErr(errors.Wrapf(err, "Error waiting for instance (%s) to be deleted", d.Id()))
 	}
 
 	d.SetId("")
@@ -156,13 +241,57 @@ func kafkaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 	}
 
 	kafka, resp, err := api.KafkaMgmt().GetKafkaById(ctx, d.Id()).Execute()
+	if utils.IsNotFound(resp, err) {
+		log.Printf("[WARN] Kafka instance (%s) not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
 			return diag.FromErr(apiErr)
 		}
 	}
 
-	err = setResourceDataFromKafkaData(d, &kafka)
+	current := &kafka
+
+	waitForReady, ok := d.Get("wait_for_ready").(bool)
+	if !ok {
+		return diag.Errorf("There was a problem getting the wait for ready value in the schema resource")
+	}
+
+	// the creation was interrupted before the instance was ready, so the wait is resumed within what is left of
+	// the create timeout, and of the read timeout
+	if waitForReady && isKafkaPending(kafka.GetStatus()) {
+		timeout := time.Until(kafka.GetCreatedAt().Add(d.Timeout(schema.TimeoutCreate)))
+		if readTimeout := d.Timeout(schema.TimeoutRead); readTimeout < timeout {
+			timeout = readTimeout
+		}
+		if timeout > 0 {
+			log.Printf("[INFO] Kafka instance (%s) is %s, resuming waiting for it to be ready", d.Id(), kafka.GetStatus())
+
+			last, waitErr := waitForKafkaReady(ctx, api, d.Id(), timeout)
+			if last != nil {
+				current = last
+			}
+			if waitErr != nil && current.GetStatus() != kafkaStatusFailed {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Kafka instance is not ready",
+					Detail:   fmt.Sprintf("Kafka instance %q (%s) did not become ready within the create timeout: %s", kafka.GetName(), d.Id(), waitErr),
+				})
+			}
+		}
+	}
+
+	if current.GetStatus() == kafkaStatusFailed {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Kafka instance has failed",
+			Detail:   fmt.Sprintf("Kafka instance %q (%s) has failed and will be replaced: %s", current.GetName(), d.Id(), current.GetFailedReason()),
+		})
+	}
+
+	err = setResourceDataFromKafkaData(d, current)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -184,7 +313,21 @@ func kafkaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.FromErr(err)
 	}
 
+	adoptExisting, ok := d.Get("adopt_existing").(bool)
+	if !ok {
+		return diag.Errorf("There was a problem getting the adopt existing value in the schema resource")
+	}
+
 	kr, resp, err := api.KafkaMgmt().CreateKafka(ctx).Async(true).KafkaRequestPayload(*requestPayload).Execute()
+	if err != nil && adoptExisting && isDuplicateKafkaName(resp, err) {
+		existing, adoptErr := findKafkaToAdopt(ctx, api, requestPayload)
+		if adoptErr != nil {
+			return diag.FromErr(errors.Wrapf(adoptErr, "a Kafka instance named %s already exists but cannot be adopted", requestPayload.GetName()))
+		}
+
+		log.Printf("[INFO] Adopting the existing Kafka instance %s (%s)", existing.GetName(), existing.GetId())
+		kr, err = *existing, nil
+	}
 	if err != nil {
 		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
 			return diag.FromErr(apiErr)
@@ -197,45 +340,88 @@ func kafkaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 
 	d.SetId(kr.Id)
 
-	createStateConf := &resource.StateChangeConf{
-		Delay: 5 * time.Second,
-		Pending: []string{
-			"accepted",
-			"preparing",
-			"provisioning",
-		},
-		Refresh: func() (interface{}, string, error) {
-			api, ok := m.(rhoasAPI.Clients)
-			if !ok {
-				return nil, "", errors.Errorf("unable to cast %v to rhoasAPI.Clients)", m)
+	waitForReady, ok := d.Get("wait_for_ready").(bool)
+	if !ok {
+		return diag.Errorf("There was a problem getting the wait for ready value in the schema resource")
+	}
+
+	if !waitForReady {
+		if owner, _ := d.Get("owner").(string); owner != "" && owner != kr.GetOwner() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Kafka instance ownership not transferred",
+				Detail:   fmt.Sprintf("The ownership of Kafka instance %q (%s) is transferred to %s once it is ready, on the next apply, as wait_for_ready is false.", kr.GetName(), kr.GetId(), owner),
+			})
+		}
+
+		if err = setResourceDataFromKafkaData(d, &kr); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
+	}
+
+	kafka, err := waitForKafkaReady(ctx, api, d.Id(), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		// the state is saved with the id, so the instance is tainted and replaced on the next apply
+		if kafka != nil {
+			if setErr := setResourceDataFromKafkaData(d, kafka); setErr != nil {
+				return diag.FromErr(setErr)
 			}
+		}
+		return diag.FromErr(errors.Wrapf(err, "Error waiting for instance (%s) to be created", d.Id()))
+	}
 
-			kafka, resp, err1 := api.KafkaMgmt().GetKafkaById(ctx, kr.Id).Execute()
-			if err1 != nil {
-				if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
-					return nil, "", apiErr
-				}
+	// the owner cannot be set when the kafka instance is created, so it is transferred to the configured owner
+	// once the instance is ready
+	owner, ok := d.Get("owner").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the owner value in the schema resource")
+	}
+
+	if owner != "" && owner != kafka.GetOwner() {
+		request := kafkamgmtclient.NewKafkaUpdateRequest()
+		request.SetOwner(owner)
+
+		updated, resp, err := api.KafkaMgmt().UpdateKafkaById(ctx, d.Id()).KafkaUpdateRequest(*request).Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return diag.FromErr(errors.Wrapf(apiErr, "the Kafka instance (%s) was created but its ownership could not be transferred to %s", d.Id(), owner))
 			}
+		}
+		kafka = &updated
+	}
 
-			return kafka, kafka.GetStatus(), nil
-		},
-		Target: []string{
-			"ready",
-		},
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
-		MinTimeout:                5 * time.Second,
-		NotFoundChecks:            0,
-		ContinuousTargetOccurence: 0,
+	err = setResourceDataFromKafkaData(d, kafka)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	data, err := createStateConf.WaitForStateContext(ctx)
+	return diags
+}
+
+func kafkaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients)", m)
+	}
+
+	if !d.HasChanges("owner", "reauthentication_enabled") {
+		return diags
+	}
+
+	request, err := mapResourceDataToKafkaUpdateRequest(d)
 	if err != nil {
-		return diag.FromErr(errors.Wrapf(err, "Error waiting for instance (%s) to be created", d.Id()))
+		return diag.FromErr(err)
 	}
 
-	kafka, castOk := data.(kafkamgmtclient.KafkaRequest)
-	if !castOk {
-		return diag.Errorf("Cannot cast data from kafka creation to to map[string]interface{}")
+	kafka, resp, err := api.KafkaMgmt().UpdateKafkaById(ctx, d.Id()).KafkaUpdateRequest(*request).Execute()
+	if err != nil {
+		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+			return diag.FromErr(apiErr)
+		}
 	}
 
 	err = setResourceDataFromKafkaData(d, &kafka)
@@ -246,6 +432,156 @@ func kafkaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 	return diags
 }
 
+// findKafkaToAdopt returns the Kafka instance with the name of the payload when it is owned by the caller and in the
+// cloud provider and region of the payload
+func findKafkaToAdopt(ctx context.Context, api rhoasAPI.Clients, payload *kafkamgmtclient.KafkaRequestPayload) (*kafkamgmtclient.KafkaRequest, error) {
+	kafka, err := getKafkaByName(ctx, api.KafkaMgmt(), payload.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := api.Username()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to check the owner of the Kafka instance")
+	}
+
+	if err = validateKafkaToAdopt(kafka, username, payload); err != nil {
+		return nil, err
+	}
+
+	return kafka, nil
+}
+
+// checkKafkaDeletionProtection refuses the deletion of a Kafka instance protected either by its deletion_protection or
+// by a name pattern of the provider
+func checkKafkaDeletionProtection(d *schema.ResourceData, patterns []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	name, ok := d.Get("name").(string)
+	if !ok {
+		return diag.Errorf("There was a problem getting the name value in the schema resource")
+	}
+
+	for _, pattern := range patterns {
+		// the patterns are validated when the provider is configured
+		if matched, _ := path.Match(pattern, name); matched {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Kafka instance is protected from deletion",
+				Detail: fmt.Sprintf("Kafka instance %q (%s) matches the pattern %q of the provider kafka_deletion_protection and cannot be deleted by Terraform. "+
+					"Remove the pattern from the provider configuration to delete it.", name, d.Id(), pattern),
+			})
+		}
+	}
+
+	deletionProtection, ok := d.Get("deletion_protection").(bool)
+	if !ok {
+		return diag.Errorf("There was a problem getting the deletion protection value in the schema resource")
+	}
+
+	// an instance that failed to be created does not hold any data, so it can be replaced
+	if deletionProtection && d.Get("status") != kafkaStatusFailed {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Kafka instance is protected from deletion",
+			Detail: fmt.Sprintf("Kafka instance %q (%s) has deletion_protection enabled and cannot be deleted by Terraform. "+
+				"Set deletion_protection to false and apply the change before deleting it.", name, d.Id()),
+		})
+	}
+
+	return diags
+}
+
+// kafkaCustomizeDiff replaces failed Kafka instances and checks that the organization is entitled to the plan of a
+// new Kafka instance in its cloud provider and region
+func kafkaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.Get("status") == kafkaStatusFailed {
+		if err := d.SetNewComputed("status"); err != nil {
+			return err
+		}
+		if err := d.ForceNew("status"); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() != "" && !d.HasChange("plan") {
+		return nil
+	}
+
+	if !d.NewValueKnown("plan") || !d.NewValueKnown("cloud_provider") || !d.NewValueKnown("region") {
+		return nil
+	}
+
+	plan, ok := d.Get("plan").(string)
+	if !ok {
+		return errors.Errorf("There was a problem getting the plan value in the schema resource")
+	}
+
+	// the service picks the default plan
+	if plan == "" {
+		return nil
+	}
+
+	cloudProvider, ok := d.Get("cloud_provider").(string)
+	if !ok {
+		return errors.Errorf("There was a problem getting the cloud provider value in the schema resource")
+	}
+
+	region, ok := d.Get("region").(string)
+	if !ok {
+		return errors.Errorf("There was a problem getting the region value in the schema resource")
+	}
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return errors.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	instanceTypes, err := getInstanceTypes(ctx, api.KafkaMgmt(), cloudProvider, region)
+	if err != nil {
+		return err
+	}
+
+	return validatePlan(plan, cloudProvider, region, instanceTypes)
+}
+
+// waitForKafkaReady waits until the Kafka instance is ready, failing when it ends up failed or is being deleted. The
+// last state of the instance is returned, even when the wait fails, as long as it could be read once.
+func waitForKafkaReady(ctx context.Context, api rhoasAPI.Clients, id string, timeout time.Duration) (*kafkamgmtclient.KafkaRequest, error) {
+	var last *kafkamgmtclient.KafkaRequest
+
+	refresh := func() (interface{}, string, error) {
+		kafka, resp, err := api.KafkaMgmt().GetKafkaById(ctx, id).Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, "", apiErr
+			}
+		}
+
+		last = &kafka
+
+		switch kafka.GetStatus() {
+		case kafkaStatusFailed:
+			return nil, "", errors.Errorf("Kafka instance (%s) has failed: %s", id, kafka.GetFailedReason())
+		case kafkaStatusDeprovision, kafkaStatusDeleting:
+			return nil, "", errors.Errorf("Kafka instance (%s) is being deleted", id)
+		}
+
+		return kafka, kafka.GetStatus(), nil
+	}
+
+	// the instance is checked once before waiting, so that no delay is added when it is ready already
+	_, status, err := refresh()
+	if err != nil || status == kafkaStatusReady {
+		return last, err
+	}
+
+	createStateConf := api.WaitConfig().StateChangeConf(kafkaPendingStatuses, []string{kafkaStatusReady}, refresh, timeout)
+
+	_, err = createStateConf.WaitForStateContext(ctx)
+	return last, err
+}
+
 func setResourceDataFromKafkaData(d *schema.ResourceData, kafka *kafkamgmtclient.KafkaRequest) error {
 	var err error
 
@@ -261,6 +597,26 @@ func setResourceDataFromKafkaData(d *schema.ResourceData, kafka *kafkamgmtclient
 		return err
 	}
 
+	if err = d.Set("plan", kafkaPlan(kafka)); err != nil {
+		return err
+	}
+
+	if err = d.Set("billing_model", kafka.GetBillingModel()); err != nil {
+		return err
+	}
+
+	if err = d.Set("marketplace", kafka.GetMarketplace()); err != nil {
+		return err
+	}
+
+	if err = d.Set("billing_cloud_account_id", kafka.GetBillingCloudAccountId()); err != nil {
+		return err
+	}
+
+	if err = d.Set("reauthentication_enabled", kafka.GetReauthenticationEnabled()); err != nil {
+		return err
+	}
+
 	if err = d.Set("href", kafka.GetHref()); err != nil {
 		return err
 	}
@@ -297,6 +653,10 @@ func setResourceDataFromKafkaData(d *schema.ResourceData, kafka *kafkamgmtclient
 		return err
 	}
 
+	if err = d.Set("failed_reason", kafka.GetFailedReason()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -320,10 +680,85 @@ func mapResourceDataToKafkaPayload(d *schema.ResourceData) (*kafkamgmtclient.Kaf
 		return nil, errors.Errorf("There was a problem getting the name value in the schema resource")
 	}
 
+	plan, ok := d.Get("plan").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the plan value in the schema resource")
+	}
+
+	billingModel, ok := d.Get("billing_model").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the billing model value in the schema resource")
+	}
+
+	marketplace, ok := d.Get("marketplace").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the marketplace value in the schema resource")
+	}
+
+	billingCloudAccountID, ok := d.Get("billing_cloud_account_id").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the billing cloud account id value in the schema resource")
+	}
+
 	payload := kafkamgmtclient.NewKafkaRequestPayload(name)
 
 	payload.SetCloudProvider(cloudProvider)
 	payload.SetRegion(region)
 
+	// the optional values are only sent when they are set, so that the service applies its defaults
+	if plan != "" {
+		payload.SetPlan(plan)
+	}
+
+	if billingModel != "" {
+		payload.SetBillingModel(billingModel)
+	}
+
+	if marketplace != "" {
+		payload.SetMarketplace(marketplace)
+	}
+
+	if billingCloudAccountID != "" {
+		payload.SetBillingCloudAccountId(billingCloudAccountID)
+	}
+
+	//nolint:staticcheck // GetOkExists is the only way to tell an unset bool from false
+	if reauthenticationEnabled, set := d.GetOkExists("reauthentication_enabled"); set {
+		enabled, ok := reauthenticationEnabled.(bool)
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the reauthentication enabled value in the schema resource")
+		}
+		payload.SetReauthenticationEnabled(enabled)
+	}
+
 	return payload, nil
 }
+
+// mapResourceDataToKafkaUpdateRequest only sets the values that changed, so that an unchanged owner is never sent
+// back to the API
+func mapResourceDataToKafkaUpdateRequest(d *schema.ResourceData) (*kafkamgmtclient.KafkaUpdateRequest, error) {
+	request := kafkamgmtclient.NewKafkaUpdateRequest()
+
+	if d.HasChange("owner") {
+		owner, ok := d.Get("owner").(string)
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the owner value in the schema resource")
+		}
+
+		// removing the owner from the configuration keeps the current owner
+		if owner != "" {
+			request.SetOwner(owner)
+		}
+	}
+
+	if d.HasChange("reauthentication_enabled") {
+		reauthenticationEnabled, ok := d.Get("reauthentication_enabled").(bool)
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the reauthentication enabled value in the schema resource")
+		}
+
+		request.SetReauthenticationEnabled(reauthenticationEnabled)
+	}
+
+	return request, nil
+}
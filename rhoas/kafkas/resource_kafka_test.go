@@ -0,0 +1,124 @@
+package kafkas_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/kafkas"
+)
+
+func TestKafkaUpdateInPlace(t *testing.T) {
+	resource := kafkas.ResourceKafka()
+	state := &terraform.InstanceState{
+		ID: "c8jd7k0o6j2l0j6b0k5g",
+		Attributes: map[string]string{
+			"id":                       "c8jd7k0o6j2l0j6b0k5g",
+			"name":                     "prices",
+			"cloud_provider":           "aws",
+			"region":                   "us-east-1",
+			"plan":                     "standard.x1",
+			"owner":                    "alice",
+			"reauthentication_enabled": "true",
+		},
+	}
+
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                     "prices",
+		"owner":                    "bob",
+		"reauthentication_enabled": false,
+	}), nil)
+	assert.NoError(t, err, "got unexpected error while planning the update")
+	assert.False(t, diff.RequiresNew(), "changing the owner or the reauthentication should not replace the Kafka instance")
+	assert.Equal(t, "bob", diff.Attributes["owner"].New, "expected the owner to be transferred")
+	assert.Equal(t, "false", diff.Attributes["reauthentication_enabled"].New, "expected the reauthentication to be disabled")
+}
+
+func TestMapResourceDataToKafkaUpdateRequest(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, kafkas.ResourceKafka().Schema, map[string]interface{}{
+		"name":  "prices",
+		"owner": "bob",
+	})
+
+	request, err := kafkas.MapResourceDataToKafkaUpdateRequest(d)
+	assert.NoError(t, err, "got unexpected error while mapping the update")
+	assert.Equal(t, "bob", request.GetOwner(), "expected the owner to be transferred")
+	_, set := request.GetReauthenticationEnabledOk()
+	assert.False(t, set, "expected the unchanged reauthentication not to be sent")
+}
+
+func TestCheckKafkaDeletionProtection(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]interface{}
+		patterns  []string
+		protected bool
+	}{
+		{
+			name:      "protected by default",
+			config:    map[string]interface{}{"name": "prices"},
+			protected: true,
+		},
+		{
+			name:   "failed instance",
+			config: map[string]interface{}{"name": "prices", "status": "failed"},
+		},
+		{
+			name:   "protection disabled",
+			config: map[string]interface{}{"name": "prices", "deletion_protection": false},
+		},
+		{
+			name:      "protected by the provider",
+			config:    map[string]interface{}{"name": "prod-prices", "deletion_protection": false},
+			patterns:  []string{"staging-*", "prod-*"},
+			protected: true,
+		},
+		{
+			name:     "not matching the provider patterns",
+			config:   map[string]interface{}{"name": "dev-prices", "deletion_protection": false},
+			patterns: []string{"prod-*"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, kafkas.ResourceKafka().Schema, tt.config)
+			d.SetId("c8jd7k0o6j2l0j6b0k5g")
+
+			diags := kafkas.CheckKafkaDeletionProtection(d, tt.patterns)
+			assert.Equal(t, tt.protected, diags.HasError(), "unexpected deletion protection")
+		})
+	}
+}
+
+func TestKafkaReplaceFailed(t *testing.T) {
+	resource := kafkas.ResourceKafka()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "prices",
+	})
+
+	state := func(status string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "c8jd7k0o6j2l0j6b0k5g",
+			Attributes: map[string]string{
+				"id":                  "c8jd7k0o6j2l0j6b0k5g",
+				"name":                "prices",
+				"cloud_provider":      "aws",
+				"region":              "us-east-1",
+				"deletion_protection": "true",
+				"status":              status,
+			},
+		}
+	}
+
+	diff, err := resource.Diff(context.Background(), state("failed"), config, nil)
+	assert.NoError(t, err, "got unexpected error while planning a failed instance")
+	assert.True(t, diff.RequiresNew(), "expected a failed instance to be replaced")
+
+	diff, err = resource.Diff(context.Background(), state("ready"), config, nil)
+	assert.NoError(t, err, "got unexpected error while planning a ready instance")
+	assert.False(t, diff != nil && diff.RequiresNew(), "expected a ready instance not to be replaced")
+}
@@ -0,0 +1,297 @@
+package kafkas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	kafkamgmtv1errors "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/error"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+const (
+	kafkaStatusReady       = "ready"
+	kafkaStatusFailed      = "failed"
+	kafkaStatusDeprovision = "deprovision"
+	kafkaStatusDeleting    = "deleting"
+)
+
+// kafkaPendingStatuses are the statuses of a Kafka instance that is being created
+var kafkaPendingStatuses = []string{"accepted", "preparing", "provisioning"}
+
+// kafkaPageSize is the number of Kafka instances requested per page when listing them
+const kafkaPageSize = 100
+
+var orRegexp = regexp.MustCompile(`(?i)\sor\s`)
+
+// planRegexp matches a plan in the format <instance_type>.<size>
+var planRegexp = regexp.MustCompile(`^[a-z0-9-]+\.[a-z0-9-]+$`)
+
+// listKafkas returns every Kafka instance matching the search, going through all the pages of the list
+func listKafkas(ctx context.Context, api kafkamgmtclient.DefaultApi, search string, orderBy string) ([]kafkamgmtclient.KafkaRequest, error) {
+	var kafkas []kafkamgmtclient.KafkaRequest
+
+	for page := 1; ; page++ {
+		request := api.GetKafkas(ctx).
+			Page(strconv.Itoa(page)).
+			Size(strconv.Itoa(kafkaPageSize))
+
+		if search != "" {
+			request = request.Search(search)
+		}
+
+		if orderBy != "" {
+			request = request.OrderBy(orderBy)
+		}
+
+		list, resp, err := request.Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+
+		items := list.GetItems()
+		kafkas = append(kafkas, items...)
+
+		if len(items) == 0 || int32(page*kafkaPageSize) >= list.GetTotal() {
+			return kafkas, nil
+		}
+	}
+}
+
+// getKafkaByName returns the only Kafka instance with exactly the given name
+func getKafkaByName(ctx context.Context, api kafkamgmtclient.DefaultApi, name string) (*kafkamgmtclient.KafkaRequest, error) {
+	kafkas, err := listKafkas(ctx, api, fmt.Sprintf("name = %s", name), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return selectKafkaByName(kafkas, name)
+}
+
+// selectKafkaByName returns the only Kafka instance with exactly the given name
+func selectKafkaByName(kafkas []kafkamgmtclient.KafkaRequest, name string) (*kafkamgmtclient.KafkaRequest, error) {
+	var matches []kafkamgmtclient.KafkaRequest
+	for i := range kafkas {
+		if kafkas[i].GetName() == name {
+			matches = append(matches, kafkas[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.Errorf("no Kafka instance found with name %q", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for i := range matches {
+			ids = append(ids, matches[i].GetId())
+		}
+		return nil, errors.Errorf("%d Kafka instances found with name %q, use the id of one of them instead: %s",
+			len(matches), name, strings.Join(ids, ", "))
+	}
+}
+
+// buildKafkasSearch combines the search criteria with the status and owner filters
+func buildKafkasSearch(search string, status string, owner string) (string, error) {
+	var criteria []string
+
+	if search != "" {
+		// the API does not support parentheses, so an OR in the search would take precedence over the filters
+		if (status != "" || owner != "") && orRegexp.MatchString(search) {
+			return "", errors.Errorf("status and owner cannot be combined with a search using OR, add them to the search instead")
+		}
+		criteria = append(criteria, search)
+	}
+
+	if status != "" {
+		criteria = append(criteria, fmt.Sprintf("status = %s", status))
+	}
+
+	if owner != "" {
+		criteria = append(criteria, fmt.Sprintf("owner = %s", owner))
+	}
+
+	return strings.Join(criteria, " and "), nil
+}
+
+// getInstanceTypes returns the instance types the organization can create in the region of the cloud provider
+func getInstanceTypes(ctx context.Context, api kafkamgmtclient.DefaultApi, cloudProvider string, region string) ([]kafkamgmtclient.SupportedKafkaInstanceType, error) {
+	list, resp, err := api.GetInstanceTypesByCloudProviderAndRegion(ctx, cloudProvider, region).Execute()
+	if err != nil {
+		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	return list.GetInstanceTypes(), nil
+}
+
+// getAvailableSizes returns the sizes that can currently be created in the region of the cloud provider, by instance
+// type
+func getAvailableSizes(ctx context.Context, api kafkamgmtclient.DefaultApi, cloudProvider string, region string) (map[string][]string, error) {
+	for page := 1; ; page++ {
+		list, resp, err := api.GetCloudProviderRegions(ctx, cloudProvider).
+			Page(strconv.Itoa(page)).
+			Size(strconv.Itoa(kafkaPageSize)).
+			Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+
+		items := list.GetItems()
+		for i := range items {
+			if items[i].GetId() != region {
+				continue
+			}
+
+			availableSizes := map[string][]string{}
+			for _, capacity := range items[i].GetCapacity() {
+				availableSizes[capacity.GetInstanceType()] = capacity.GetAvailableSizes()
+			}
+			return availableSizes, nil
+		}
+
+		if len(items) == 0 || int32(page*kafkaPageSize) >= list.GetTotal() {
+			return nil, errors.Errorf("region %q not found for cloud provider %q", region, cloudProvider)
+		}
+	}
+}
+
+// flattenInstanceTypes converts the instance types into the attributes of the instance types data source, marking
+// the sizes that can currently be created as available
+func flattenInstanceTypes(instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType, availableSizes map[string][]string) []interface{} {
+	flattened := make([]interface{}, 0, len(instanceTypes))
+	for i := range instanceTypes {
+		available := map[string]bool{}
+		for _, size := range availableSizes[instanceTypes[i].GetId()] {
+			available[size] = true
+		}
+
+		sizes := instanceTypes[i].GetSizes()
+		flattenedSizes := make([]interface{}, 0, len(sizes))
+		for j := range sizes {
+			flattenedSizes = append(flattenedSizes, map[string]interface{}{
+				"id":                              sizes[j].GetId(),
+				"display_name":                    sizes[j].GetDisplayName(),
+				"available":                       available[sizes[j].GetId()],
+				"maturity_status":                 sizes[j].GetMaturityStatus(),
+				"ingress_throughput_per_sec":      bytesValue(sizes[j].GetIngressThroughputPerSec()),
+				"egress_throughput_per_sec":       bytesValue(sizes[j].GetEgressThroughputPerSec()),
+				"max_partitions":                  int(sizes[j].GetMaxPartitions()),
+				"total_max_connections":           int(sizes[j].GetTotalMaxConnections()),
+				"max_connection_attempts_per_sec": int(sizes[j].GetMaxConnectionAttemptsPerSec()),
+				"max_data_retention_size":         bytesValue(sizes[j].GetMaxDataRetentionSize()),
+				"max_data_retention_period":       sizes[j].GetMaxDataRetentionPeriod(),
+				"max_message_size":                bytesValue(sizes[j].GetMaxMessageSize()),
+				"min_in_sync_replicas":            int(sizes[j].GetMinInSyncReplicas()),
+				"replication_factor":              int(sizes[j].GetReplicationFactor()),
+				"supported_az_modes":              sizes[j].GetSupportedAzModes(),
+				"lifespan_seconds":                int(sizes[j].GetLifespanSeconds()),
+				"quota_consumed":                  int(sizes[j].GetQuotaConsumed()),
+				"quota_type":                      sizes[j].GetQuotaType(),
+				"capacity_consumed":               int(sizes[j].GetCapacityConsumed()),
+			})
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"id":           instanceTypes[i].GetId(),
+			"display_name": instanceTypes[i].GetDisplayName(),
+			"available":    len(available) > 0,
+			"sizes":        flattenedSizes,
+		})
+	}
+	return flattened
+}
+
+func bytesValue(item kafkamgmtclient.SupportedKafkaSizeBytesValueItem) int {
+	return int(item.GetBytes())
+}
+
+// kafkaPlan returns the plan of the Kafka instance in the format <instance_type>.<size>
+func kafkaPlan(kafka *kafkamgmtclient.KafkaRequest) string {
+	if kafka.GetInstanceType() == "" || kafka.GetSizeId() == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s.%s", kafka.GetInstanceType(), kafka.GetSizeId())
+}
+
+// validatePlan checks that the plan is one of the instance types and sizes the organization is entitled to
+func validatePlan(plan string, cloudProvider string, region string, instanceTypes []kafkamgmtclient.SupportedKafkaInstanceType) error {
+	instanceType, size, found := strings.Cut(plan, ".")
+	if !found {
+		return errors.Errorf("plan %q must be in the format <instance_type>.<size>", plan)
+	}
+
+	ids := make([]string, 0, len(instanceTypes))
+	for i := range instanceTypes {
+		ids = append(ids, instanceTypes[i].GetId())
+		if instanceTypes[i].GetId() != instanceType {
+			continue
+		}
+
+		sizes := instanceTypes[i].GetSizes()
+		sizeIDs := make([]string, 0, len(sizes))
+		for j := range sizes {
+			if sizes[j].GetId() == size {
+				return nil
+			}
+			sizeIDs = append(sizeIDs, sizes[j].GetId())
+		}
+
+		return errors.Errorf("size %q of plan %q is not available for instance type %q in %s %s, the available sizes are: %s",
+			size, plan, instanceType, cloudProvider, region, strings.Join(sizeIDs, ", "))
+	}
+
+	return errors.Errorf("the organization is not entitled to instance type %q of plan %q in %s %s, the instance types it is entitled to are: %s",
+		instanceType, plan, cloudProvider, region, strings.Join(ids, ", "))
+}
+
+// isKafkaPending checks whether the status is the one of a Kafka instance that is being created
+func isKafkaPending(status string) bool {
+	for _, pending := range kafkaPendingStatuses {
+		if status == pending {
+			return true
+		}
+	}
+	return false
+}
+
+// isDuplicateKafkaName checks whether the creation of a Kafka instance failed because its name is already used
+func isDuplicateKafkaName(response *http.Response, apiError error) bool {
+	if response != nil && response.StatusCode == http.StatusConflict {
+		return true
+	}
+
+	return kafkamgmtv1errors.IsAPIError(apiError, kafkamgmtv1errors.ERROR_36) ||
+		kafkamgmtv1errors.IsAPIError(apiError, kafkamgmtv1errors.ERROR_6)
+}
+
+// validateKafkaToAdopt checks that an existing Kafka instance is owned by username and matches the cloud provider,
+// region and plan of the payload
+func validateKafkaToAdopt(kafka *kafkamgmtclient.KafkaRequest, username string, payload *kafkamgmtclient.KafkaRequestPayload) error {
+	if kafka.GetOwner() != username {
+		return errors.Errorf("it is owned by %s rather than %s", kafka.GetOwner(), username)
+	}
+
+	if kafka.GetCloudProvider() != payload.GetCloudProvider() || kafka.GetRegion() != payload.GetRegion() {
+		return errors.Errorf("it is in %s %s rather than %s %s", kafka.GetCloudProvider(), kafka.GetRegion(),
+			payload.GetCloudProvider(), payload.GetRegion())
+	}
+
+	if payload.GetPlan() != "" && kafkaPlan(kafka) != payload.GetPlan() {
+		return errors.Errorf("its plan is %s rather than %s", kafkaPlan(kafka), payload.GetPlan())
+	}
+
+	return nil
+}
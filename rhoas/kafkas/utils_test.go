@@ -0,0 +1,222 @@
+package kafkas_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/kafkas"
+)
+
+func TestSelectKafkaByName(t *testing.T) {
+	kafka := func(id string, name string) kafkamgmtclient.KafkaRequest {
+		request := kafkamgmtclient.NewKafkaRequestWithDefaults()
+		request.SetId(id)
+		request.SetName(name)
+		return *request
+	}
+
+	list := []kafkamgmtclient.KafkaRequest{
+		kafka("c8jd7k0o6j2l0j6b0k5g", "prices"),
+		kafka("c8jd7k0o6j2l0j6b0k6g", "prices-dev"),
+		kafka("c8jd7k0o6j2l0j6b0k7g", "orders"),
+		kafka("c8jd7k0o6j2l0j6b0k8g", "orders"),
+	}
+
+	t.Run("single match", func(t *testing.T) {
+		selected, err := kafkas.SelectKafkaByName(list, "prices")
+		assert.NoError(t, err, "got unexpected error while selecting a Kafka instance by name")
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g", selected.GetId(), "the Kafka instance with exactly the name should be selected")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := kafkas.SelectKafkaByName(list, "billing")
+		assert.EqualError(t, err, `no Kafka instance found with name "billing"`)
+	})
+
+	t.Run("several matches", func(t *testing.T) {
+		_, err := kafkas.SelectKafkaByName(list, "orders")
+		assert.EqualError(t, err, `2 Kafka instances found with name "orders", use the id of one of them instead: c8jd7k0o6j2l0j6b0k7g, c8jd7k0o6j2l0j6b0k8g`)
+	})
+}
+
+func TestBuildKafkasSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		search   string
+		status   string
+		owner    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "no criteria", expected: ""},
+		{name: "search only", search: "name like prices%", expected: "name like prices%"},
+		{name: "filters only", status: "ready", owner: "jdoe", expected: "status = ready and owner = jdoe"},
+		{name: "search and filters", search: "region = us-east-1", status: "ready", expected: "region = us-east-1 and status = ready"},
+		{name: "search with or only", search: "name = prices or name = orders", expected: "name = prices or name = orders"},
+		{name: "search with or and filters", search: "name = prices OR name = orders", owner: "jdoe", wantErr: true},
+		{name: "name containing or", search: "name = prices-or-orders", owner: "jdoe", expected: "name = prices-or-orders and owner = jdoe"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			search, err := kafkas.BuildKafkasSearch(tt.search, tt.status, tt.owner)
+			if tt.wantErr {
+				assert.Error(t, err, "expected an error when combining a search using OR with filters")
+				return
+			}
+			assert.NoError(t, err, "got unexpected error while building the search")
+			assert.Equal(t, tt.expected, search, "unexpected search")
+		})
+	}
+}
+
+func TestFlattenInstanceTypes(t *testing.T) {
+	size := func(id string, maxPartitions int32) kafkamgmtclient.SupportedKafkaSize {
+		ingress := kafkamgmtclient.NewSupportedKafkaSizeBytesValueItem()
+		ingress.SetBytes(52428800)
+
+		result := kafkamgmtclient.NewSupportedKafkaSize()
+		result.SetId(id)
+		result.SetMaxPartitions(maxPartitions)
+		result.SetIngressThroughputPerSec(*ingress)
+		result.SetQuotaConsumed(1)
+		return *result
+	}
+
+	standard := kafkamgmtclient.NewSupportedKafkaInstanceType()
+	standard.SetId("standard")
+	standard.SetSizes([]kafkamgmtclient.SupportedKafkaSize{size("x1", 1500), size("x2", 3000)})
+
+	developer := kafkamgmtclient.NewSupportedKafkaInstanceType()
+	developer.SetId("developer")
+	developer.SetSizes([]kafkamgmtclient.SupportedKafkaSize{size("x1", 100)})
+
+	instanceTypes := kafkas.FlattenInstanceTypes(
+		[]kafkamgmtclient.SupportedKafkaInstanceType{*standard, *developer},
+		map[string][]string{"standard": {"x1"}, "developer": {}},
+	)
+
+	d := schema.TestResourceDataRaw(t, kafkas.DataSourceKafkaInstanceTypes().Schema, map[string]interface{}{
+		"cloud_provider": "aws",
+		"region":         "us-east-1",
+	})
+	err := d.Set("instance_types", instanceTypes)
+	assert.NoError(t, err, "the flattened instance types do not match the schema")
+
+	assert.Equal(t, true, d.Get("instance_types.0.available"), "expected the standard instance type to be available")
+	assert.Equal(t, true, d.Get("instance_types.0.sizes.0.available"), "expected the x1 size to be available")
+	assert.Equal(t, false, d.Get("instance_types.0.sizes.1.available"), "expected the x2 size not to be available")
+	assert.Equal(t, 3000, d.Get("instance_types.0.sizes.1.max_partitions"), "unexpected max partitions")
+	assert.Equal(t, 52428800, d.Get("instance_types.0.sizes.1.ingress_throughput_per_sec"), "unexpected ingress throughput")
+	assert.Equal(t, 1, d.Get("instance_types.0.sizes.1.quota_consumed"), "unexpected quota consumed")
+	assert.Equal(t, false, d.Get("instance_types.1.available"), "expected the developer instance type not to be available")
+}
+
+func TestValidatePlan(t *testing.T) {
+	instanceType := func(id string, sizes ...string) kafkamgmtclient.SupportedKafkaInstanceType {
+		supportedSizes := make([]kafkamgmtclient.SupportedKafkaSize, 0, len(sizes))
+		for _, size := range sizes {
+			supportedSize := kafkamgmtclient.NewSupportedKafkaSize()
+			supportedSize.SetId(size)
+			supportedSizes = append(supportedSizes, *supportedSize)
+		}
+
+		result := kafkamgmtclient.NewSupportedKafkaInstanceType()
+		result.SetId(id)
+		result.SetSizes(supportedSizes)
+		return *result
+	}
+
+	instanceTypes := []kafkamgmtclient.SupportedKafkaInstanceType{
+		instanceType("standard", "x1", "x2"),
+		instanceType("developer", "x1"),
+	}
+
+	tests := []struct {
+		name    string
+		plan    string
+		wantErr string
+	}{
+		{name: "entitled", plan: "standard.x2"},
+		{name: "unknown size", plan: "developer.x2", wantErr: "the available sizes are: x1"},
+		{name: "not entitled", plan: "enterprise.x1", wantErr: "the instance types it is entitled to are: standard, developer"},
+		{name: "invalid format", plan: "standard", wantErr: "must be in the format"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := kafkas.ValidatePlan(tt.plan, "aws", "us-east-1", instanceTypes)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "expected the plan to be valid")
+				return
+			}
+			if assert.Error(t, err, "expected the plan to be invalid") {
+				assert.Contains(t, err.Error(), tt.wantErr, "unexpected error")
+			}
+		})
+	}
+}
+
+func TestKafkaPlan(t *testing.T) {
+	kafka := kafkamgmtclient.NewKafkaRequestWithDefaults()
+	assert.Equal(t, "", kafkas.KafkaPlan(kafka), "expected no plan without an instance type")
+
+	kafka.SetInstanceType("standard")
+	kafka.SetSizeId("x1")
+	assert.Equal(t, "standard.x1", kafkas.KafkaPlan(kafka), "unexpected plan")
+}
+
+func TestSetResourceDataFromKafkaData(t *testing.T) {
+	kafka := kafkamgmtclient.NewKafkaRequestWithDefaults()
+	kafka.SetId("c8jd7k0o6j2l0j6b0k5g")
+	kafka.SetName("prices")
+	kafka.SetInstanceType("standard")
+	kafka.SetSizeId("x1")
+	kafka.SetBillingModel("marketplace")
+	kafka.SetMarketplace("aws")
+	kafka.SetReauthenticationEnabled(true)
+
+	// the resource and the data source share the function, so it must match both schemas
+	for name, resource := range map[string]*schema.Resource{
+		"resource":    kafkas.ResourceKafka(),
+		"data source": kafkas.DataSourceKafka(),
+	} {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+
+		err := kafkas.SetResourceDataFromKafkaData(d, kafka)
+		assert.NoError(t, err, "the Kafka instance does not match the %s schema", name)
+		assert.Equal(t, "standard.x1", d.Get("plan"), "unexpected plan in the %s", name)
+		assert.Equal(t, "marketplace", d.Get("billing_model"), "unexpected billing model in the %s", name)
+		assert.Equal(t, true, d.Get("reauthentication_enabled"), "unexpected reauthentication in the %s", name)
+	}
+}
+
+func TestValidateKafkaToAdopt(t *testing.T) {
+	kafka := kafkamgmtclient.NewKafkaRequestWithDefaults()
+	kafka.SetName("prices")
+	kafka.SetOwner("alice")
+	kafka.SetCloudProvider("aws")
+	kafka.SetRegion("us-east-1")
+	kafka.SetInstanceType("standard")
+	kafka.SetSizeId("x1")
+
+	payload := func(cloudProvider string, region string, plan string) *kafkamgmtclient.KafkaRequestPayload {
+		result := kafkamgmtclient.NewKafkaRequestPayload("prices")
+		result.SetCloudProvider(cloudProvider)
+		result.SetRegion(region)
+		if plan != "" {
+			result.SetPlan(plan)
+		}
+		return result
+	}
+
+	assert.NoError(t, kafkas.ValidateKafkaToAdopt(kafka, "alice", payload("aws", "us-east-1", "")), "expected the instance to be adopted")
+	assert.NoError(t, kafkas.ValidateKafkaToAdopt(kafka, "alice", payload("aws", "us-east-1", "standard.x1")), "expected the instance to be adopted")
+	assert.Error(t, kafkas.ValidateKafkaToAdopt(kafka, "bob", payload("aws", "us-east-1", "")), "expected an instance owned by another user not to be adopted")
+	assert.Error(t, kafkas.ValidateKafkaToAdopt(kafka, "alice", payload("aws", "eu-west-1", "")), "expected an instance in another region not to be adopted")
+	assert.Error(t, kafkas.ValidateKafkaToAdopt(kafka, "alice", payload("aws", "us-east-1", "standard.x2")), "expected an instance with another plan not to be adopted")
+}
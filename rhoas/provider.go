@@ -3,16 +3,28 @@ package rhoas
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	authAPI "github.com/redhat-developer/app-services-sdk-go/auth/apiv1"
 	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
-	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	serviceaccountmgmt "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/acls"
 	rhoasClients "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/clients"
 	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/cloudproviders"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/consumergroups"
 	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/kafkas"
 	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/serviceaccounts"
 	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/topics"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 const (
@@ -39,7 +51,20 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CLIENT_ID", authAPI.DefaultClientID),
-				Description: fmt.Sprintf("The client id is used to when getting the access token using the offline token. By default %s is used.", authAPI.DefaultClientID),
+				Description: fmt.Sprintf("The client id is used to when getting the access token using the offline token. By default %s is used. When `client_secret` is set this must be the client id of the service account used to authenticate.", authAPI.DefaultClientID),
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CLIENT_SECRET", nil),
+				Description: "The client secret of the service account used to authenticate. When set, the provider uses the OAuth2 client credentials flow with `client_id` and `client_secret` instead of the offline token. As the client secret is a sensitive value it is best specified using the `CLIENT_SECRET` environment variable.",
+			},
+			"token_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TOKEN_URL", nil),
+				Description: "The token url is used to get an access token for the service account when authenticating with `client_id` and `client_secret`. By default it is derived from the auth url.",
 			},
 			"api_url": {
 				Type:        schema.TypeString,
@@ -47,39 +72,208 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("API_URL", DefaultAPIURL),
 				Description: fmt.Sprintf("URL to the RHOAS services API. By default using production API (%s).", DefaultAPIURL),
 			},
+			"service_accounts_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SERVICE_ACCOUNTS_URL", nil),
+				Description: "URL to the service account management API. By default the auth url is used.",
+			},
+			"kafka_admin_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KAFKA_ADMIN_URL", nil),
+				Description: "URL to the Kafka instance admin API. By default the admin API URL reported by each Kafka instance is used. This is mainly useful when running against a local or mock environment.",
+			},
+			"poll_interval": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("POLL_INTERVAL", utils.DefaultWaitConfig.PollInterval.String()),
+				Description: fmt.Sprintf("The interval between two polls of the API while waiting for an object to change state, for example for a Kafka instance to be ready. The interval is multiplied by `poll_backoff_multiplier` after every poll, up to `max_poll_interval`. By default %s is used.", utils.DefaultWaitConfig.PollInterval),
+			},
+			"max_poll_interval": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MAX_POLL_INTERVAL", utils.DefaultWaitConfig.MaxPollInterval.String()),
+				Description: fmt.Sprintf("The longest interval between two polls of the API while waiting for an object to change state. It must be less than %s. By default %s is used.", utils.MaxPollInterval, utils.DefaultWaitConfig.MaxPollInterval),
+			},
+			"poll_backoff_multiplier": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				Default:          utils.DefaultWaitConfig.BackoffMultiplier,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(1)),
+				Description:      fmt.Sprintf("The factor the interval between two polls of the API is multiplied by after every poll. Use 1 to poll at a constant interval. By default %v is used.", utils.DefaultWaitConfig.BackoffMultiplier),
+			},
+			"kafka_deletion_protection": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Glob patterns, for example `prod-*`, of the names of the Kafka instances that must never be deleted. The deletion of a matching `rhoas_kafka` is refused whatever the value of its `deletion_protection`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"rhoas_kafka":           kafkas.ResourceKafka(),
-			"rhoas_topic":           topics.ResourceTopic(),
-			"rhoas_service_account": serviceaccounts.ResourceServiceAccount(),
+			"rhoas_kafka":                       kafkas.ResourceKafka(),
+			"rhoas_topic":                       topics.ResourceTopic(),
+			"rhoas_service_account":             serviceaccounts.ResourceServiceAccount(),
+			"rhoas_acl":                         acls.ResourceACL(),
+			"rhoas_kafka_access":                acls.ResourceKafkaAccess(),
+			"rhoas_consumer_group_offset_reset": consumergroups.ResourceConsumerGroupOffsetReset(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"rhoas_cloud_providers":        cloudproviders.DataSourceCloudProviders(),
 			"rhoas_cloud_provider_regions": cloudproviders.DataSourceCloudProviderRegions(),
 			"rhoas_kafkas":                 kafkas.DataSourceKafkas(),
 			"rhoas_kafka":                  kafkas.DataSourceKafka(),
+			"rhoas_kafka_instance_types":   kafkas.DataSourceKafkaInstanceTypes(),
+			"rhoas_kafka_ready":            kafkas.DataSourceKafkaReady(),
 			"rhoas_service_accounts":       serviceaccounts.DataSourceServiceAccounts(),
+			"rhoas_service_account":        serviceaccounts.DataSourceServiceAccount(),
+			"rhoas_consumer_groups":        consumergroups.DataSourceConsumerGroups(),
+			"rhoas_consumer_group":         consumergroups.DataSourceConsumerGroup(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	httpClient := authAPI.BuildAuthenticatedHTTPClient(d.Get("offline_token").(string))
+	httpClient, diags := buildAuthenticatedHTTPClient(d)
+	if diags.HasError() {
+		return nil, diags
+	}
 
 	kafkaClient := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+		BaseURL:    d.Get("api_url").(string),
 		HTTPClient: httpClient,
 	})
 
-	config := serviceAccounts.NewConfiguration()
-	config.HTTPClient = httpClient
-	serviceAccountClient := serviceAccounts.NewAPIClient(config)
+	// the service account management API is served by the auth server unless told otherwise
+	serviceAccountsURL := d.Get("service_accounts_url").(string)
+	if serviceAccountsURL == "" {
+		serviceAccountsURL = d.Get("auth_url").(string)
+	}
+
+	serviceAccountClient := serviceaccountmgmt.NewAPIClient(&serviceaccountmgmt.Config{
+		BaseURL:    serviceAccountsURL,
+		HTTPClient: httpClient,
+	})
 
 	// package both service account client and kafka client together to be used in the provider
 	// these are passed to each action we do and can be use to CRUD kafkas/serviceAccounts
-	client := rhoasClients.NewDefaultClient(kafkaClient, serviceAccountClient, httpClient)
+	deletionProtectionPatterns, patternDiags := getKafkaDeletionProtectionPatterns(d)
+	diags = append(diags, patternDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	waitConfig, waitDiags := getWaitConfig(d)
+	diags = append(diags, waitDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client := rhoasClients.NewDefaultClient(kafkaClient, serviceAccountClient, httpClient, d.Get("kafka_admin_url").(string), deletionProtectionPatterns, waitConfig)
 
 	return client, diags
 }
+
+// getKafkaDeletionProtectionPatterns returns the name patterns of the Kafka instances that must never be deleted,
+// checking that they are valid glob patterns
+func getKafkaDeletionProtectionPatterns(d *schema.ResourceData) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	rawPatterns := d.Get("kafka_deletion_protection").([]interface{})
+	patterns := make([]string, 0, len(rawPatterns))
+	for _, rawPattern := range rawPatterns {
+		pattern, _ := rawPattern.(string)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid Kafka deletion protection pattern",
+				Detail:   fmt.Sprintf("%q in `kafka_deletion_protection` is not a valid glob pattern: %s", pattern, err),
+			})
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, diags
+}
+
+// getWaitConfig returns how the API is polled while waiting for an object to change state
+func getWaitConfig(d *schema.ResourceData) (utils.WaitConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	waitConfig := utils.WaitConfig{
+		BackoffMultiplier: d.Get("poll_backoff_multiplier").(float64),
+	}
+
+	pollInterval, err := time.ParseDuration(d.Get("poll_interval").(string))
+	if err != nil || pollInterval <= 0 {
+		return waitConfig, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid poll interval",
+			Detail:   fmt.Sprintf("`poll_interval` must be a positive duration, for example 5s, got %q", d.Get("poll_interval")),
+		})
+	}
+	waitConfig.PollInterval = pollInterval
+
+	maxPollInterval, err := time.ParseDuration(d.Get("max_poll_interval").(string))
+	if err != nil || maxPollInterval < pollInterval || maxPollInterval >= utils.MaxPollInterval {
+		return waitConfig, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid maximum poll interval",
+			Detail: fmt.Sprintf("`max_poll_interval` must be a duration of at least `poll_interval` (%s) and less than %s, got %q",
+				pollInterval, utils.MaxPollInterval, d.Get("max_poll_interval")),
+		})
+	}
+	waitConfig.MaxPollInterval = maxPollInterval
+
+	return waitConfig, diags
+}
+
+// buildAuthenticatedHTTPClient returns an http client which adds an access token to every request. The token is
+// obtained either from the offline token or, when a client secret is configured, from the service account
+// credentials using the client credentials flow. In both cases the token is refreshed automatically when it expires.
+func buildAuthenticatedHTTPClient(d *schema.ResourceData) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	offlineToken := d.Get("offline_token").(string)
+	clientSecret := d.Get("client_secret").(string)
+
+	if clientSecret == "" {
+		return authAPI.BuildAuthenticatedHTTPClientCustom(offlineToken, d.Get("client_id").(string), d.Get("auth_url").(string)), diags
+	}
+
+	if offlineToken != "" {
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Conflicting authentication configuration",
+			Detail:   "Both an offline token and a client secret are configured. Set either `offline_token` or `client_id` and `client_secret`, but not both.",
+		})
+	}
+
+	clientID := d.Get("client_id").(string)
+	if clientID == "" || clientID == authAPI.DefaultClientID {
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing service account client id",
+			Detail:   "`client_id` must be set to the client id of the service account when authenticating with `client_secret`.",
+		})
+	}
+
+	tokenURL := d.Get("token_url").(string)
+	if tokenURL == "" {
+		tokenURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(d.Get("auth_url").(string), "/"), "protocol/openid-connect/token")
+	}
+
+	cfg := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+
+	// the token source is used for the whole lifetime of the provider, so it must not be bound to the
+	// context of the configure call
+	return cfg.Client(context.Background()), diags
+}
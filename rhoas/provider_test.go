@@ -0,0 +1,143 @@
+package rhoas_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas"
+	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
+)
+
+func TestProvider(t *testing.T) {
+	assert.NoError(t, rhoas.Provider().InternalValidate(), "the provider schema is invalid")
+}
+
+func TestProviderConfigure(t *testing.T) {
+	// make sure the environment of the machine running the tests does not leak into the configuration
+	for _, env := range []string{"OFFLINE_TOKEN", "AUTH_URL", "CLIENT_ID", "CLIENT_SECRET", "TOKEN_URL", "API_URL", "SERVICE_ACCOUNTS_URL", "KAFKA_ADMIN_URL", "POLL_INTERVAL", "MAX_POLL_INTERVAL"} {
+		t.Setenv(env, "")
+	}
+
+	configure := func(raw map[string]interface{}) (interface{}, bool) {
+		provider := rhoas.Provider()
+		d := schema.TestResourceDataRaw(t, provider.Schema, raw)
+		client, diags := provider.ConfigureContextFunc(context.Background(), d)
+		return client, diags.HasError()
+	}
+
+	t.Run("offline token", func(t *testing.T) {
+		client, hasError := configure(map[string]interface{}{
+			"offline_token": "token",
+		})
+		assert.False(t, hasError, "got unexpected error while configuring the provider with an offline token")
+		assert.NotNil(t, client, "expected a client to be returned")
+	})
+
+	t.Run("offline token and client secret", func(t *testing.T) {
+		_, hasError := configure(map[string]interface{}{
+			"offline_token": "token",
+			"client_id":     "srvc-acct-1",
+			"client_secret": "secret",
+		})
+		assert.True(t, hasError, "expected an error when both authentication modes are configured")
+	})
+
+	t.Run("invalid kafka deletion protection pattern", func(t *testing.T) {
+		_, hasError := configure(map[string]interface{}{
+			"offline_token":             "token",
+			"kafka_deletion_protection": []interface{}{"prod-*", "[prod"},
+		})
+		assert.True(t, hasError, "expected an error when a deletion protection pattern is invalid")
+	})
+
+	t.Run("poll intervals", func(t *testing.T) {
+		client, hasError := configure(map[string]interface{}{
+			"offline_token":     "token",
+			"poll_interval":     "2s",
+			"max_poll_interval": "1m",
+		})
+		assert.False(t, hasError, "got unexpected error while configuring the poll intervals")
+		assert.Equal(t, time.Minute, client.(rhoasAPI.Clients).WaitConfig().MaxPollInterval, "unexpected maximum poll interval")
+	})
+
+	t.Run("maximum poll interval below the poll interval", func(t *testing.T) {
+		_, hasError := configure(map[string]interface{}{
+			"offline_token":     "token",
+			"poll_interval":     "30s",
+			"max_poll_interval": "10s",
+		})
+		assert.True(t, hasError, "expected an error when the maximum poll interval is below the poll interval")
+	})
+
+	t.Run("client secret with default client id", func(t *testing.T) {
+		_, hasError := configure(map[string]interface{}{
+			"client_secret": "secret",
+		})
+		assert.True(t, hasError, "expected an error when the client id is not set to a service account")
+	})
+
+	t.Run("client secret with empty client id", func(t *testing.T) {
+		_, hasError := configure(map[string]interface{}{
+			"client_id":     "",
+			"client_secret": "secret",
+		})
+		assert.True(t, hasError, "expected an error when the client id is empty")
+	})
+
+	t.Run("token url derived from auth url", func(t *testing.T) {
+		var tokenPath string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				tokenPath = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":300}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, hasError := configure(map[string]interface{}{
+			"auth_url":      server.URL + "/auth/realms/test/",
+			"client_id":     "srvc-acct-1",
+			"client_secret": "secret",
+		})
+		assert.False(t, hasError, "got unexpected error while configuring the provider with a service account")
+
+		resp, err := client.(rhoasAPI.Clients).HTTPClient().Get(server.URL)
+		assert.NoError(t, err, "got unexpected error while sending an authenticated request")
+		resp.Body.Close()
+		assert.Equal(t, "/auth/realms/test/protocol/openid-connect/token", tokenPath, "the token was requested from an unexpected url")
+	})
+
+	t.Run("explicit token url", func(t *testing.T) {
+		var tokenPath string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				tokenPath = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":300}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, hasError := configure(map[string]interface{}{
+			"token_url":     server.URL + "/token",
+			"client_id":     "srvc-acct-1",
+			"client_secret": "secret",
+		})
+		assert.False(t, hasError, "got unexpected error while configuring the provider with a service account")
+
+		resp, err := client.(rhoasAPI.Clients).HTTPClient().Get(server.URL)
+		assert.NoError(t, err, "got unexpected error while sending an authenticated request")
+		resp.Body.Close()
+		assert.Equal(t, "/token", tokenPath, "the token was requested from an unexpected url")
+	})
+}
@@ -2,11 +2,12 @@ package serviceaccounts
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
 	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
-	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
 )
 
 func DataSourceServiceAccount() *schema.Resource {
@@ -16,12 +17,19 @@ func DataSourceServiceAccount() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"client_id": {
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
-				Description: "The client id associated with the service account",
+				Description: "The client id associated with the service account. Exactly one of `id`, `client_id` or `name` must be set.",
+				ExactlyOneOf: []string{
+					"id",
+					"client_id",
+					"name",
+				},
 			},
 			"href": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path to the service account in the REST API",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -29,9 +37,10 @@ func DataSourceServiceAccount() *schema.Resource {
 				Description: "A description of the service account",
 			},
 			"id": {
-				Description: "The unique identifier for the service account",
+				Description: "The unique identifier for the service account. Exactly one of `id`, `client_id` or `name` must be set.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"kind": {
 				Type:        schema.TypeString,
@@ -39,8 +48,9 @@ func DataSourceServiceAccount() *schema.Resource {
 				Description: "The kind of resource in the API",
 			},
 			"name": {
-				Description: "The name of the service account",
+				Description: "The exact name of the service account. It is an error if no or more than one service account has the name. Exactly one of `id`, `client_id` or `name` must be set.",
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
 			},
 			"owner": {
@@ -65,23 +75,49 @@ func dataSourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("unable to cast %v to rhoasAPI.Clients)", m)
 	}
 
-	val := d.Get("id")
-	id, ok := val.(string)
-	if !ok {
-		return diag.Errorf("unable to cast %v to string for use as for service account id", val)
+	values := map[string]string{}
+	for _, key := range []string{"id", "client_id", "name"} {
+		val := d.Get(key)
+		value, ok := val.(string)
+		if !ok {
+			return diag.Errorf("unable to cast %v to string for use as for service account %s", val, key)
+		}
+		values[key] = value
 	}
 
-	serviceAccount, resp, err := api.ServiceAccountMgmt().GetServiceAccount(ctx, id).Execute()
+	var serviceAccount *serviceAccounts.ServiceAccountData
+	var err error
+
+	switch {
+	case values["id"] != "":
+		serviceAccount, err = getServiceAccountByID(ctx, api.ServiceAccountMgmt(), values["id"])
+	case values["client_id"] != "":
+		serviceAccount, err = getServiceAccountByClientID(ctx, api.ServiceAccountMgmt(), values["client_id"])
+	default:
+		serviceAccount, err = getServiceAccountByName(ctx, api.ServiceAccountMgmt(), values["name"])
+	}
 	if err != nil {
-		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
-			return diag.FromErr(apiErr)
-		}
+		return diag.FromErr(err)
 	}
 
-	err = setResourceDataFromServiceAccountData(d, &serviceAccount)
+	d.SetId(serviceAccount.GetId())
+
+	err = setResourceDataFromServiceAccountData(d, serviceAccount)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err = d.Set("owner", serviceAccount.GetCreatedBy()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("created_at", time.Unix(serviceAccount.GetCreatedAt(), 0).UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("href", serviceAccountHref(serviceAccount.GetId())); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
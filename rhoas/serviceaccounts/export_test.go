@@ -0,0 +1,7 @@
+package serviceaccounts
+
+// expose the unexported functions of the package to the tests in serviceaccounts_test
+var (
+	SecretRotationRequired     = secretRotationRequired
+	SelectServiceAccountByName = selectServiceAccountByName
+)
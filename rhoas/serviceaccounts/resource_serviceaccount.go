@@ -2,10 +2,12 @@ package serviceaccounts
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
 	rhoasAPI "redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/api"
@@ -17,20 +19,23 @@ func ResourceServiceAccount() *schema.Resource {
 		Description:   "`rhoas_service_account` manages a service account in Red Hat OpenShift Streams for Apache Kafka.",
 		CreateContext: serviceAccountCreate,
 		ReadContext:   serviceAccountRead,
+		UpdateContext: serviceAccountUpdate,
 		DeleteContext: serviceAccountDelete,
+		CustomizeDiff: serviceAccountCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Schema: map[string]*schema.Schema{
 			"description": {
 				Description: "A description of the service account",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
-				ForceNew:    true,
 			},
 			"name": {
 				Description: "The name of the service account",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"client_id": {
 				Description: "The client id associated with the service account",
@@ -42,9 +47,29 @@ func ResourceServiceAccount() *schema.Resource {
 				Computed:    true,
 				Description: "The client secret associated with the service account. It must be stored by the client as the server will not return it after creation",
 			},
+			"rotation_triggers": {
+				Description: "Arbitrary values that rotate the client secret whenever they change. The client id does not change when the secret is rotated.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rotate_after_days": {
+				Description:      "The number of days after which the client secret is rotated on the next apply. The client secret of an imported service account, or of one created before `secret_rotated_at` was tracked, is rotated on the next apply.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"secret_rotated_at": {
+				Description: "The RFC3339 date and time at which the client secret was created or last rotated",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -81,6 +106,11 @@ func serviceAccountRead(ctx context.Context, d *schema.ResourceData, m interface
 	// the resource data ID field is the same as the service account id which is set when the
 	// service account is created
 	serviceAccount, resp, err := api.ServiceAccountMgmt().GetServiceAccount(ctx, d.Id()).Execute()
+	if utils.IsNotFound(resp, err) {
+		log.Printf("[WARN] Service account (%s) not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
 			return diag.FromErr(apiErr)
@@ -123,9 +153,127 @@ func serviceAccountCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	err = setResourceDataSecret(d, &serviceAccount)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
+func serviceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients)", m)
+	}
+
+	if d.HasChanges("name", "description") {
+		request, err := mapResourceDataToServiceAccountUpdateRequest(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		serviceAccount, resp, err := api.ServiceAccountMgmt().UpdateServiceAccount(ctx, d.Id()).ServiceAccountRequestData(*request).Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return diag.FromErr(apiErr)
+			}
+		}
+
+		err = setResourceDataFromServiceAccountData(d, &serviceAccount)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	rotate, err := secretRotationRequired(d, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if rotate {
+		serviceAccount, resp, err := api.ServiceAccountMgmt().ResetServiceAccountSecret(ctx, d.Id()).Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return diag.FromErr(apiErr)
+			}
+		}
+
+		err = setResourceDataSecret(d, &serviceAccount)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+// serviceAccountCustomizeDiff plans a new client secret when the rotation triggers change or the secret is older
+// than rotate_after_days
+func serviceAccountCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	rotate, err := secretRotationRequired(d, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	if err = d.SetNewComputed("client_secret"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("secret_rotated_at")
+}
+
+// secretGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type secretGetter interface {
+	Get(key string) interface{}
+	HasChange(key string) bool
+}
+
+// secretRotationRequired checks whether the client secret has to be rotated, either because the rotation triggers
+// changed or because the secret is older than rotate_after_days
+func secretRotationRequired(d secretGetter, now time.Time) (bool, error) {
+	if d.HasChange("rotation_triggers") {
+		return true, nil
+	}
+
+	rotateAfterDays, ok := d.Get("rotate_after_days").(int)
+	if !ok {
+		return false, errors.Errorf("There was a problem getting the rotate after days value in the schema resource")
+	}
+
+	if rotateAfterDays == 0 {
+		return false, nil
+	}
+
+	rotatedAt, ok := d.Get("secret_rotated_at").(string)
+	if !ok {
+		return false, errors.Errorf("There was a problem getting the secret rotated at value in the schema resource")
+	}
+
+	// the age of the secret is unknown, so it is rotated to start tracking it
+	if rotatedAt == "" {
+		return true, nil
+	}
+
+	rotatedAtTime, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to parse secret_rotated_at %q", rotatedAt)
+	}
+
+	return !now.Before(rotatedAtTime.AddDate(0, 0, rotateAfterDays)), nil
+}
+
 func mapResourceDataToServiceAccountCreateRequest(d *schema.ResourceData) (*serviceAccounts.ServiceAccountCreateRequestData, error) {
 
 	// we only set these values from the resource data as all the rest are set as
@@ -147,6 +295,25 @@ func mapResourceDataToServiceAccountCreateRequest(d *schema.ResourceData) (*serv
 	return request, nil
 }
 
+func mapResourceDataToServiceAccountUpdateRequest(d *schema.ResourceData) (*serviceAccounts.ServiceAccountRequestData, error) {
+	description, ok := d.Get("description").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the description value in the schema resource")
+	}
+
+	name, ok := d.Get("name").(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the name value in the schema resource")
+	}
+
+	// the client id and secret are not part of the request, so they are kept when the service account is updated
+	request := serviceAccounts.NewServiceAccountRequestData()
+	request.SetName(name)
+	request.SetDescription(description)
+
+	return request, nil
+}
+
 func setResourceDataFromServiceAccountData(d *schema.ResourceData, serviceAccount *serviceAccounts.ServiceAccountData) error {
 	var err error
 
@@ -164,3 +331,19 @@ func setResourceDataFromServiceAccountData(d *schema.ResourceData, serviceAccoun
 
 	return nil
 }
+
+// setResourceDataSecret stores the client secret, which is only returned by the API when the service account is
+// created and when its secret is reset
+func setResourceDataSecret(d *schema.ResourceData, serviceAccount *serviceAccounts.ServiceAccountData) error {
+	var err error
+
+	if err = d.Set("client_secret", serviceAccount.GetSecret()); err != nil {
+		return err
+	}
+
+	if err = d.Set("secret_rotated_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package serviceaccounts_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/serviceaccounts"
+)
+
+func TestSecretRotationRequired(t *testing.T) {
+	resource := serviceaccounts.ResourceServiceAccount()
+	now := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		raw       map[string]interface{}
+		rotatedAt string
+		expected  bool
+	}{
+		{
+			name:      "no rotation configured",
+			raw:       map[string]interface{}{"name": "foo"},
+			rotatedAt: "2020-01-01T00:00:00Z",
+			expected:  false,
+		},
+		{
+			name:      "secret younger than rotate_after_days",
+			raw:       map[string]interface{}{"name": "foo", "rotate_after_days": 30},
+			rotatedAt: "2022-06-01T12:00:01Z",
+			expected:  false,
+		},
+		{
+			name:      "secret as old as rotate_after_days",
+			raw:       map[string]interface{}{"name": "foo", "rotate_after_days": 30},
+			rotatedAt: "2022-05-31T12:00:00Z",
+			expected:  true,
+		},
+		{
+			name:      "unknown age",
+			raw:       map[string]interface{}{"name": "foo", "rotate_after_days": 30},
+			rotatedAt: "",
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resource.Schema, tt.raw)
+			assert.NoError(t, d.Set("secret_rotated_at", tt.rotatedAt), "unable to set secret_rotated_at")
+
+			rotate, err := serviceaccounts.SecretRotationRequired(d, now)
+			assert.NoError(t, err, "got unexpected error while checking the secret rotation")
+			assert.Equal(t, tt.expected, rotate, "unexpected secret rotation")
+		})
+	}
+}
+
+func TestServiceAccountCustomizeDiffRotationTriggers(t *testing.T) {
+	resource := serviceaccounts.ResourceServiceAccount()
+	state := &terraform.InstanceState{
+		ID: "0b3ba5d2-5e5c-4e2f-bf0d-9a8a1d6e8f54",
+		Attributes: map[string]string{
+			"id":                         "0b3ba5d2-5e5c-4e2f-bf0d-9a8a1d6e8f54",
+			"name":                       "foo",
+			"description":                "",
+			"client_id":                  "srvc-acct-1",
+			"client_secret":              "secret",
+			"secret_rotated_at":          time.Now().UTC().Format(time.RFC3339),
+			"rotation_triggers.%":        "1",
+			"rotation_triggers.revision": "1",
+		},
+	}
+
+	config := func(revision string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":              "foo",
+			"rotation_triggers": map[string]interface{}{"revision": revision},
+		})
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, config("1"), nil)
+		assert.NoError(t, err, "got unexpected error while keeping the rotation triggers")
+		assert.True(t, diff == nil || diff.Empty(), "expected no changes")
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, config("2"), nil)
+		assert.NoError(t, err, "got unexpected error while changing the rotation triggers")
+		assert.False(t, diff.RequiresNew(), "rotating the secret should not replace the service account")
+		assert.True(t, diff.Attributes["client_secret"].NewComputed, "expected a new client secret to be planned")
+		assert.True(t, diff.Attributes["secret_rotated_at"].NewComputed, "expected a new rotation time to be planned")
+	})
+}
+
+func TestServiceAccountDiffNameAndDescription(t *testing.T) {
+	resource := serviceaccounts.ResourceServiceAccount()
+	state := &terraform.InstanceState{
+		ID: "0b3ba5d2-5e5c-4e2f-bf0d-9a8a1d6e8f54",
+		Attributes: map[string]string{
+			"id":                "0b3ba5d2-5e5c-4e2f-bf0d-9a8a1d6e8f54",
+			"name":              "foo",
+			"description":       "producer for the prcies topics",
+			"client_id":         "srvc-acct-1",
+			"client_secret":     "secret",
+			"secret_rotated_at": time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "bar",
+		"description": "producer for the prices topics",
+	}), nil)
+	assert.NoError(t, err, "got unexpected error while changing the name and description")
+	assert.False(t, diff.RequiresNew(), "changing the name and description should not replace the service account")
+	assert.Equal(t, "bar", diff.Attributes["name"].New, "expected the name to be changed")
+	assert.Equal(t, "producer for the prices topics", diff.Attributes["description"].New, "expected the description to be changed")
+	assert.NotContains(t, diff.Attributes, "client_id", "the client id should be kept")
+	assert.NotContains(t, diff.Attributes, "client_secret", "the client secret should be kept")
+}
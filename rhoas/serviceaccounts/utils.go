@@ -1,5 +1,18 @@
 package serviceaccounts
 
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+// serviceAccountPageSize is the maximum page size supported by the service account API
+const serviceAccountPageSize int32 = 100
+
 func fixClientIDAndClientSecret(items []map[string]interface{}, existingClientSecret *string) []map[string]interface{} {
 	// Fix the client id and client secret
 	answer := make([]map[string]interface{}, 0)
@@ -11,3 +24,82 @@ func fixClientIDAndClientSecret(items []map[string]interface{}, existingClientSe
 	}
 	return answer
 }
+
+// serviceAccountHref returns the path to the service account in the REST API, as the API does not return it
+func serviceAccountHref(id string) string {
+	return fmt.Sprintf("/apis/service_accounts/v1/%s", id)
+}
+
+func getServiceAccountByID(ctx context.Context, api serviceAccounts.ServiceAccountsApi, id string) (*serviceAccounts.ServiceAccountData, error) {
+	serviceAccount, resp, err := api.GetServiceAccount(ctx, id).Execute()
+	if err != nil {
+		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	return &serviceAccount, nil
+}
+
+func getServiceAccountByClientID(ctx context.Context, api serviceAccounts.ServiceAccountsApi, clientID string) (*serviceAccounts.ServiceAccountData, error) {
+	list, resp, err := api.GetServiceAccounts(ctx).ClientId([]string{clientID}).Execute()
+	if err != nil {
+		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	for i := range list {
+		if list[i].GetClientId() == clientID {
+			return &list[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("no service account found with client id %q", clientID)
+}
+
+func getServiceAccountByName(ctx context.Context, api serviceAccounts.ServiceAccountsApi, name string) (*serviceAccounts.ServiceAccountData, error) {
+	var list []serviceAccounts.ServiceAccountData
+
+	// the API cannot filter by name, so go through every page
+	for first := int32(0); ; first += serviceAccountPageSize {
+		page, resp, err := api.GetServiceAccounts(ctx).First(first).Max(serviceAccountPageSize).Execute()
+		if err != nil {
+			if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+
+		list = append(list, page...)
+
+		if int32(len(page)) < serviceAccountPageSize {
+			break
+		}
+	}
+
+	return selectServiceAccountByName(list, name)
+}
+
+// selectServiceAccountByName returns the only service account with exactly the given name
+func selectServiceAccountByName(list []serviceAccounts.ServiceAccountData, name string) (*serviceAccounts.ServiceAccountData, error) {
+	var matches []serviceAccounts.ServiceAccountData
+	for i := range list {
+		if list[i].GetName() == name {
+			matches = append(matches, list[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.Errorf("no service account found with name %q", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for i := range matches {
+			ids = append(ids, matches[i].GetId())
+		}
+		return nil, errors.Errorf("%d service accounts found with name %q, use the id or client_id of one of them instead: %s",
+			len(matches), name, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,41 @@
+package serviceaccounts_test
+
+import (
+	"testing"
+
+	serviceAccounts "github.com/redhat-developer/app-services-sdk-go/serviceaccountmgmt/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/serviceaccounts"
+)
+
+func TestSelectServiceAccountByName(t *testing.T) {
+	serviceAccount := func(id string, name string) serviceAccounts.ServiceAccountData {
+		data := serviceAccounts.NewServiceAccountData()
+		data.SetId(id)
+		data.SetName(name)
+		return *data
+	}
+
+	list := []serviceAccounts.ServiceAccountData{
+		serviceAccount("1", "producer"),
+		serviceAccount("2", "consumer"),
+		serviceAccount("3", "consumer"),
+		serviceAccount("4", "producer-2"),
+	}
+
+	t.Run("single match", func(t *testing.T) {
+		selected, err := serviceaccounts.SelectServiceAccountByName(list, "producer")
+		assert.NoError(t, err, "got unexpected error while selecting a service account by name")
+		assert.Equal(t, "1", selected.GetId(), "the service account with exactly the name should be selected")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := serviceaccounts.SelectServiceAccountByName(list, "prod")
+		assert.EqualError(t, err, `no service account found with name "prod"`)
+	})
+
+	t.Run("several matches", func(t *testing.T) {
+		_, err := serviceaccounts.SelectServiceAccountByName(list, "consumer")
+		assert.EqualError(t, err, `2 service accounts found with name "consumer", use the id or client_id of one of them instead: 2, 3`)
+	})
+}
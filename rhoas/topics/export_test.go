@@ -0,0 +1,9 @@
+package topics
+
+// expose the unexported functions of the package to the tests in topics_test
+var (
+	TopicID                        = topicID
+	ParseTopicID                   = parseTopicID
+	MapResourceDataToConfigEntries = mapResourceDataToConfigEntries
+	SetResourceDataFromTopic       = setResourceDataFromTopic
+)
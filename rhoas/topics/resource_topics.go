@@ -2,6 +2,10 @@ package topics
 
 import (
 	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,9 +21,26 @@ func ResourceTopic() *schema.Resource {
 		Description:   "`rhoas_topic` manages a topic in a  Kafka instance in Red Hat OpenShift Streams for Apache Kafka.",
 		CreateContext: topicCreate,
 		ReadContext:   topicRead,
+		UpdateContext: topicUpdate,
 		DeleteContext: topicDelete,
+		CustomizeDiff: topicCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: topicImport,
+		},
+		// version 0 used the id returned by the API, which is not unique across kafka instances
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceTopicV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: topicStateUpgradeV0,
+			},
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -29,10 +50,9 @@ func ResourceTopic() *schema.Resource {
 				ForceNew:    true,
 			},
 			"partitions": {
-				Description: "The number of partitions in the topic",
+				Description: "The number of partitions in the topic. The number of partitions can be increased in place. **WARNING:** decreasing the number of partitions destroys the topic, including ALL of its data, and creates a new empty topic.",
 				Type:        schema.TypeInt,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"kafka_id": {
 				Description: "The unique ID of the kafka instance this topic is associated with",
@@ -40,6 +60,14 @@ func ResourceTopic() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"config": {
+				Description: "The configuration of the topic, for example `retention.ms` or `cleanup.policy`. Only the keys set here are managed, all other keys keep the broker defaults. Keys can be added and changed in place, but not removed as the admin API cannot reset a key to its broker default; set the key to the broker default value instead. The configuration is not imported, so it is set again on the first apply after an import.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -63,18 +91,17 @@ func topicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.FromErr(errors.Errorf("There was a problem getting the topic name value in the schema resource"))
 	}
 
-	instanceAPI, _, err := api.KafkaAdmin(&ctx, kafkaID)
+	err := rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		resp, err := instanceAPI.TopicsApi.DeleteTopic(ctx, topicName).Execute()
+		if err != nil {
+			return utils.GetAPIError(resp, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := instanceAPI.TopicsApi.DeleteTopic(ctx, topicName).Execute()
-	if err != nil {
-		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
-			return diag.FromErr(apiErr)
-		}
-	}
-
 	d.SetId("")
 	return diags
 }
@@ -98,16 +125,30 @@ func topicRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 		return diag.FromErr(errors.Errorf("There was a problem getting the topic name value in the schema resource"))
 	}
 
-	instanceAPI, _, err := api.KafkaAdmin(&ctx, kafkaID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	var topic kafkainstanceclient.Topic
+	var resp *http.Response
 
-	topic, resp, err := instanceAPI.TopicsApi.GetTopic(ctx, topicName).Execute()
-	if err != nil {
-		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
-			return diag.FromErr(apiErr)
+	err := rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		var err error
+		topic, resp, err = instanceAPI.TopicsApi.GetTopic(ctx, topicName).Execute()
+		if err != nil {
+			return utils.GetAPIError(resp, err)
 		}
+		return nil
+	})
+	// the error of a missing kafka instance is returned as is, while the error of a missing topic only has a 404 response
+	if utils.IsNotFound(nil, err) {
+		log.Printf("[WARN] Kafka instance (%s) of topic %s not found, removing the topic from the state", kafkaID, topicName)
+		d.SetId("")
+		return diags
+	}
+	if utils.IsNotFound(resp, err) {
+		log.Printf("[WARN] Topic %s not found in Kafka instance (%s), removing it from the state", topicName, kafkaID)
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	err = setResourceDataFromTopic(d, &topic)
@@ -132,23 +173,91 @@ func topicCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.FromErr(errors.Errorf("There was a problem getting the kafka ID value in the schema resource"))
 	}
 
-	instanceAPI, _, err := api.KafkaAdmin(&ctx, kafkaID)
+	var topic kafkainstanceclient.Topic
+
+	err := rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		topicRequest := instanceAPI.TopicsApi.CreateTopic(ctx)
+
+		err := mapResourceDataToTopicRequest(d, &topicRequest)
+		if err != nil {
+			return err
+		}
+
+		var resp *http.Response
+		topic, resp, err = topicRequest.Execute()
+		if err != nil {
+			return utils.GetAPIError(resp, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	topicRequest := instanceAPI.TopicsApi.CreateTopic(ctx)
-
-	err = mapResourceDataToTopicRequest(d, &topicRequest)
+	err = setResourceDataFromTopic(d, &topic)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	topic, resp, err := topicRequest.Execute()
-	if err != nil {
-		if apiErr := utils.GetAPIError(resp, err); apiErr != nil {
-			return diag.FromErr(apiErr)
+	d.SetId(topicID(kafkaID, topic.GetName()))
+
+	if err = d.Set("kafka_id", kafkaID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func topicUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	api, ok := m.(rhoasAPI.Clients)
+	if !ok {
+		return diag.Errorf("unable to cast %v to rhoasAPI.Clients", m)
+	}
+
+	kafkaID, ok := d.Get("kafka_id").(string)
+	if !ok {
+		return diag.FromErr(errors.Errorf("There was a problem getting the kafka ID value in the schema resource"))
+	}
+
+	topicName, ok := d.Get("name").(string)
+	if !ok {
+		return diag.FromErr(errors.Errorf("There was a problem getting the topic name value in the schema resource"))
+	}
+
+	settings := kafkainstanceclient.TopicSettings{}
+
+	if d.HasChange("partitions") {
+		partitions, ok := d.Get("partitions").(int)
+		if !ok {
+			return diag.Errorf("There was a problem getting the partition value in the schema resource")
+		}
+		settings.SetNumPartitions(int32(partitions))
+	}
+
+	if d.HasChange("config") {
+		config, err := mapResourceDataToConfigEntries(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		settings.SetConfig(config)
+	}
+
+	var topic kafkainstanceclient.Topic
+
+	err := rhoasAPI.RetryKafkaAdmin(ctx, api, kafkaID, func(instanceAPI *kafkainstanceclient.APIClient) error {
+		var resp *http.Response
+		var err error
+		topic, resp, err = instanceAPI.TopicsApi.UpdateTopic(ctx, topicName).TopicSettings(settings).Execute()
+		if err != nil {
+			return utils.GetAPIError(resp, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	err = setResourceDataFromTopic(d, &topic)
@@ -156,13 +265,134 @@ func topicCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.FromErr(err)
 	}
 
-	d.SetId(topic.GetId())
+	return diags
+}
+
+func topicCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if err := validateConfigChange(d); err != nil {
+		return err
+	}
+
+	// an unknown value is read as 0, which must not be mistaken for a decrease
+	if !d.HasChange("partitions") || !d.NewValueKnown("partitions") {
+		return nil
+	}
+
+	// kafka can only add partitions to a topic, so the only way to decrease them is to re-create the topic
+	oldValue, newValue := d.GetChange("partitions")
+
+	oldPartitions, ok := oldValue.(int)
+	if !ok {
+		return errors.Errorf("There was a problem getting the partition value in the schema resource")
+	}
+
+	newPartitions, ok := newValue.(int)
+	if !ok {
+		return errors.Errorf("There was a problem getting the partition value in the schema resource")
+	}
+
+	if newPartitions < oldPartitions {
+		log.Printf("[WARN] decreasing the partitions of topic %s from %d to %d requires the topic to be deleted and re-created, ALL DATA IN THE TOPIC WILL BE LOST",
+			d.Get("name"), oldPartitions, newPartitions)
+		return d.ForceNew("partitions")
+	}
+
+	return nil
+}
+
+// validateConfigChange rejects the removal of config keys, as the admin API can only set a config key and not reset it
+// to the broker default. Silently leaving the old value on the broker would make the state lie about the topic.
+func validateConfigChange(d *schema.ResourceDiff) error {
+	if !d.HasChange("config") || !d.NewValueKnown("config") {
+		return nil
+	}
+
+	oldValue, newValue := d.GetChange("config")
+
+	oldConfig, ok := oldValue.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("There was a problem getting the config value in the schema resource")
+	}
+
+	newConfig, ok := newValue.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("There was a problem getting the config value in the schema resource")
+	}
+
+	var removed []string
+	for key := range oldConfig {
+		if _, ok := newConfig[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		return errors.Errorf("config keys %s cannot be removed from topic %s as they cannot be reset to the broker defaults, set them to the broker default values instead",
+			strings.Join(removed, ", "), d.Get("name"))
+	}
+
+	return nil
+}
+
+func resourceTopicV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"partitions": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"kafka_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func topicStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	kafkaID, ok := rawState["kafka_id"].(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the kafka ID value in the topic state")
+	}
+
+	topicName, ok := rawState["name"].(string)
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the topic name value in the topic state")
+	}
+
+	rawState["id"] = topicID(kafkaID, topicName)
+
+	return rawState, nil
+}
+
+func topicImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// topics are only unique within a kafka instance, so they are imported using <kafka_id>/<topic_name>
+	kafkaID, topicName, err := parseTopicID(d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	if err = d.Set("kafka_id", kafkaID); err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
 
-	return diags
+	if err = d.Set("name", topicName); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func setResourceDataFromTopic(d *schema.ResourceData, topic *kafkainstanceclient.Topic) error {
@@ -176,6 +406,24 @@ func setResourceDataFromTopic(d *schema.ResourceData, topic *kafkainstanceclient
 		return err
 	}
 
+	// the API returns every config entry of the topic including the broker defaults, so only the keys which are
+	// managed by the resource are kept to avoid a diff for every key that is not set in the configuration
+	managedConfig, ok := d.Get("config").(map[string]interface{})
+	if !ok {
+		return errors.Errorf("There was a problem getting the config value in the schema resource")
+	}
+
+	config := map[string]interface{}{}
+	for _, entry := range topic.GetConfig() {
+		if _, managed := managedConfig[entry.GetKey()]; managed {
+			config[entry.GetKey()] = entry.GetValue()
+		}
+	}
+
+	if err = d.Set("config", config); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -196,10 +444,16 @@ func mapResourceDataToTopicRequest(d *schema.ResourceData, request *kafkainstanc
 	// as SDK requires int32
 	partitionsInt32 := int32(partitions)
 
+	config, err := mapResourceDataToConfigEntries(d)
+	if err != nil {
+		return err
+	}
+
 	topicInput := kafkainstanceclient.NewTopicInput{
 		Name: name,
 		Settings: kafkainstanceclient.TopicSettings{
 			NumPartitions: &partitionsInt32,
+			Config:        &config,
 		},
 	}
 
@@ -207,3 +461,28 @@ func mapResourceDataToTopicRequest(d *schema.ResourceData, request *kafkainstanc
 
 	return nil
 }
+
+func mapResourceDataToConfigEntries(d *schema.ResourceData) ([]kafkainstanceclient.ConfigEntry, error) {
+	config, ok := d.Get("config").(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("There was a problem getting the config value in the schema resource")
+	}
+
+	// sort the keys so the request is the same for the same configuration
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]kafkainstanceclient.ConfigEntry, 0, len(keys))
+	for _, key := range keys {
+		value, ok := config[key].(string)
+		if !ok {
+			return nil, errors.Errorf("There was a problem getting the value of config %s in the schema resource", key)
+		}
+		entries = append(entries, *kafkainstanceclient.NewConfigEntry(key, value))
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,211 @@
+package topics_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1/client"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/topics"
+)
+
+func TestTopicImport(t *testing.T) {
+	resource := topics.ResourceTopic()
+
+	t.Run("valid id", func(t *testing.T) {
+		d := resource.TestResourceData()
+		d.SetId("c8jd7k0o6j2l0j6b0k5g/prices")
+
+		imported, err := resource.Importer.StateContext(context.Background(), d, nil)
+		assert.NoError(t, err, "got unexpected error while importing a topic")
+		assert.Len(t, imported, 1, "expected a single topic to be imported")
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g", imported[0].Get("kafka_id"), "unexpected kafka id was imported")
+		assert.Equal(t, "prices", imported[0].Get("name"), "unexpected topic name was imported")
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g/prices", imported[0].Id(), "unexpected id was imported")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		d := resource.TestResourceData()
+		d.SetId("prices")
+
+		_, err := resource.Importer.StateContext(context.Background(), d, nil)
+		assert.Error(t, err, "expected an error while importing a topic without a kafka id")
+	})
+}
+
+func TestTopicStateUpgradeV0(t *testing.T) {
+	resource := topics.ResourceTopic()
+
+	assert.Equal(t, 1, resource.SchemaVersion, "unexpected schema version")
+	assert.Len(t, resource.StateUpgraders, 1, "expected a single state upgrader")
+
+	state, err := resource.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+		"id":         "",
+		"kafka_id":   "c8jd7k0o6j2l0j6b0k5g",
+		"name":       "prices",
+		"partitions": 3,
+	}, nil)
+	assert.NoError(t, err, "got unexpected error while upgrading the topic state")
+	assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g/prices", state["id"], "the id was not upgraded to <kafka_id>/<topic_name>")
+	assert.Equal(t, 3, state["partitions"], "the partitions were changed by the upgrade")
+}
+
+func TestMapResourceDataToConfigEntries(t *testing.T) {
+	resource := topics.ResourceTopic()
+
+	t.Run("sorted keys", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"config": map[string]interface{}{
+				"retention.ms":        "604800000",
+				"cleanup.policy":      "delete",
+				"min.insync.replicas": "2",
+			},
+		})
+
+		entries, err := topics.MapResourceDataToConfigEntries(d)
+		assert.NoError(t, err, "got unexpected error while mapping the config")
+		assert.Equal(t, []kafkainstanceclient.ConfigEntry{
+			*kafkainstanceclient.NewConfigEntry("cleanup.policy", "delete"),
+			*kafkainstanceclient.NewConfigEntry("min.insync.replicas", "2"),
+			*kafkainstanceclient.NewConfigEntry("retention.ms", "604800000"),
+		}, entries, "the config entries are not sorted by key")
+	})
+
+	t.Run("non string values", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"config": map[string]interface{}{
+				"retention.ms": 604800000,
+			},
+		})
+
+		entries, err := topics.MapResourceDataToConfigEntries(d)
+		assert.NoError(t, err, "got unexpected error while mapping the config")
+		assert.Equal(t, []kafkainstanceclient.ConfigEntry{
+			*kafkainstanceclient.NewConfigEntry("retention.ms", "604800000"),
+		}, entries, "the config value was not converted to a string")
+	})
+
+	t.Run("no config", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+
+		entries, err := topics.MapResourceDataToConfigEntries(d)
+		assert.NoError(t, err, "got unexpected error while mapping an empty config")
+		assert.Empty(t, entries, "expected no config entries")
+	})
+}
+
+func TestSetResourceDataFromTopic(t *testing.T) {
+	resource := topics.ResourceTopic()
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":       "prices",
+		"partitions": 1,
+		"kafka_id":   "c8jd7k0o6j2l0j6b0k5g",
+		"config": map[string]interface{}{
+			"retention.ms": "604800000",
+		},
+	})
+
+	topic := kafkainstanceclient.NewTopic()
+	topic.SetName("prices")
+	topic.SetPartitions([]kafkainstanceclient.Partition{
+		*kafkainstanceclient.NewPartition(0),
+		*kafkainstanceclient.NewPartition(1),
+	})
+	topic.SetConfig([]kafkainstanceclient.ConfigEntry{
+		*kafkainstanceclient.NewConfigEntry("cleanup.policy", "delete"),
+		*kafkainstanceclient.NewConfigEntry("retention.ms", "86400000"),
+		*kafkainstanceclient.NewConfigEntry("segment.bytes", "1073741824"),
+	})
+
+	err := topics.SetResourceDataFromTopic(d, topic)
+	assert.NoError(t, err, "got unexpected error while setting the topic")
+	assert.Equal(t, 2, d.Get("partitions"), "unexpected number of partitions")
+	assert.Equal(t, map[string]interface{}{
+		"retention.ms": "86400000",
+	}, d.Get("config"), "only the managed config keys should be kept")
+}
+
+func TestTopicCustomizeDiffConfig(t *testing.T) {
+	resource := topics.ResourceTopic()
+	state := topicState(3)
+	state.Attributes["config.%"] = "2"
+	state.Attributes["config.cleanup.policy"] = "delete"
+	state.Attributes["config.retention.ms"] = "604800000"
+
+	t.Run("key changed", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, topicConfig(3, map[string]interface{}{
+			"cleanup.policy": "compact",
+			"retention.ms":   "604800000",
+		}), nil)
+		assert.NoError(t, err, "got unexpected error while changing a config key")
+		assert.False(t, diff.RequiresNew(), "changing a config key should not replace the topic")
+	})
+
+	t.Run("key removed", func(t *testing.T) {
+		_, err := resource.Diff(context.Background(), state, topicConfig(3, map[string]interface{}{
+			"retention.ms": "604800000",
+		}), nil)
+		assert.Error(t, err, "expected an error when removing a config key")
+	})
+}
+
+func topicState(partitions int) *terraform.InstanceState {
+	return &terraform.InstanceState{
+		ID: "c8jd7k0o6j2l0j6b0k5g/prices",
+		Attributes: map[string]string{
+			"id":         "c8jd7k0o6j2l0j6b0k5g/prices",
+			"name":       "prices",
+			"kafka_id":   "c8jd7k0o6j2l0j6b0k5g",
+			"partitions": strconv.Itoa(partitions),
+		},
+	}
+}
+
+func topicConfig(partitions interface{}, config map[string]interface{}) *terraform.ResourceConfig {
+	raw := map[string]interface{}{
+		"name":       "prices",
+		"kafka_id":   "c8jd7k0o6j2l0j6b0k5g",
+		"partitions": partitions,
+	}
+	if config != nil {
+		raw["config"] = config
+	}
+	return terraform.NewResourceConfigRaw(raw)
+}
+
+func TestTopicCustomizeDiffPartitions(t *testing.T) {
+	resource := topics.ResourceTopic()
+	state := topicState(3)
+
+	t.Run("increase", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, topicConfig(6, nil), nil)
+		assert.NoError(t, err, "got unexpected error while increasing the partitions")
+		assert.Equal(t, "6", diff.Attributes["partitions"].New, "expected the partitions to be changed")
+		assert.False(t, diff.RequiresNew(), "increasing the partitions should not replace the topic")
+	})
+
+	t.Run("decrease", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, topicConfig(1, nil), nil)
+		assert.NoError(t, err, "got unexpected error while decreasing the partitions")
+		assert.True(t, diff.RequiresNew(), "decreasing the partitions should replace the topic")
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, topicConfig(3, nil), nil)
+		assert.NoError(t, err, "got unexpected error while keeping the partitions")
+		assert.True(t, diff == nil || diff.Empty(), "expected no changes")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		diff, err := resource.Diff(context.Background(), state, topicConfig(unknownValue, nil), nil)
+		assert.NoError(t, err, "got unexpected error while setting the partitions to an unknown value")
+		assert.False(t, diff.RequiresNew(), "an unknown number of partitions should not replace the topic")
+	})
+}
+
+// unknownValue is how terraform represents a value that is only known after apply in a raw config
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
@@ -0,0 +1,23 @@
+package topics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// topicID builds the terraform id of a topic, which is only unique in combination with the kafka instance it is in
+func topicID(kafkaID string, topicName string) string {
+	return fmt.Sprintf("%s/%s", kafkaID, topicName)
+}
+
+// parseTopicID splits a terraform topic id of the form <kafka_id>/<topic_name> into its parts
+func parseTopicID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("unexpected format of topic id %q, expected <kafka_id>/<topic_name>", id)
+	}
+
+	return parts[0], parts[1], nil
+}
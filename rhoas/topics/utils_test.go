@@ -0,0 +1,27 @@
+package topics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/topics"
+)
+
+func TestParseTopicID(t *testing.T) {
+	t.Run("valid id", func(t *testing.T) {
+		kafkaID, topicName, err := topics.ParseTopicID(topics.TopicID("c8jd7k0o6j2l0j6b0k5g", "prices"))
+		assert.NoError(t, err, "got unexpected error while parsing a valid topic id")
+		assert.Equal(t, "c8jd7k0o6j2l0j6b0k5g", kafkaID, "unexpected kafka id was returned")
+		assert.Equal(t, "prices", topicName, "unexpected topic name was returned")
+	})
+
+	t.Run("missing topic name", func(t *testing.T) {
+		_, _, err := topics.ParseTopicID("c8jd7k0o6j2l0j6b0k5g")
+		assert.Error(t, err, "expected an error while parsing an id without a topic name")
+	})
+
+	t.Run("empty parts", func(t *testing.T) {
+		_, _, err := topics.ParseTopicID("/prices")
+		assert.Error(t, err, "expected an error while parsing an id without a kafka id")
+	})
+}
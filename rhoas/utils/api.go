@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	kafkamgmtv1errors "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/error"
 )
 
 // AsMap converts a JSON-tagged struct into a map
@@ -25,16 +26,39 @@ func AsMap(original interface{}) (map[string]interface{}, error) {
 	return obj, nil
 }
 
-// GetAPIError converts an http.Response and a RHOAS apiError into golang errors
+// GetAPIError converts an http.Response and a RHOAS apiError into golang errors. Errors from responses with a 5xx or
+// 429 status code are marked as transient.
 func GetAPIError(response *http.Response, apiError error) error {
+	var err error
+
 	switch {
 	case apiError == nil:
-		return parseResponse(response)
+		err = parseResponse(response)
 	case response == nil:
-		return apiError
+		err = apiError
 	default:
-		return errors.Errorf("API error: %v, response error: %v", apiError, parseResponse(response))
+		err = errors.Errorf("API error: %v, response error: %v", apiError, parseResponse(response))
+	}
+
+	if err != nil && isTransientResponse(response) {
+		return Transient(err)
+	}
+
+	return err
+}
+
+// IsNotFound checks whether an API call failed because the object does not exist, either from the status code of the
+// response or from the not found error code of the Kafka management API
+func IsNotFound(response *http.Response, apiError error) bool {
+	if apiError == nil {
+		return false
 	}
+
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	return kafkamgmtv1errors.IsAPIError(apiError, kafkamgmtv1errors.ERROR_7)
 }
 
 func parseResponse(response *http.Response) error {
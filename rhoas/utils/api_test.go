@@ -96,3 +96,57 @@ func (mb erroringBuffer) Close() error {
 func (mb erroringBuffer) Read(p []byte) (n int, err error) {
 	return 0, errors.New("error reading body")
 }
+
+func TestIsNotFound(t *testing.T) {
+	testAPIError := errors.New("test")
+
+	t.Run("no api error", func(t *testing.T) {
+		assert.False(t, utils.IsNotFound(&http.Response{StatusCode: http.StatusNotFound}, nil), "expected no error not to be a not found error")
+	})
+
+	t.Run("not found response", func(t *testing.T) {
+		assert.True(t, utils.IsNotFound(&http.Response{StatusCode: http.StatusNotFound}, testAPIError), "expected a 404 response to be a not found error")
+	})
+
+	t.Run("other response", func(t *testing.T) {
+		assert.False(t, utils.IsNotFound(&http.Response{StatusCode: http.StatusInternalServerError}, testAPIError), "expected a 500 response not to be a not found error")
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		assert.False(t, utils.IsNotFound(nil, testAPIError), "expected an error without a response not to be a not found error")
+	})
+}
+
+func TestGetAPIErrorTransient(t *testing.T) {
+	testAPIError := errors.New("test")
+
+	tests := []struct {
+		name       string
+		statusCode int
+		transient  bool
+	}{
+		{name: "internal server error", statusCode: http.StatusInternalServerError, transient: true},
+		{name: "service unavailable", statusCode: http.StatusServiceUnavailable, transient: true},
+		{name: "too many requests", statusCode: http.StatusTooManyRequests, transient: true},
+		{name: "bad request", statusCode: http.StatusBadRequest, transient: false},
+		{name: "not found", statusCode: http.StatusNotFound, transient: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       io.NopCloser(strings.NewReader("response")),
+			}
+
+			err := utils.GetAPIError(response, testAPIError)
+			assert.Error(t, err, "expecting an error if we have a response and an API error")
+			assert.Equal(t, tt.transient, utils.IsTransient(err), "unexpected transient error")
+		})
+	}
+
+	t.Run("no response", func(t *testing.T) {
+		assert.False(t, utils.IsTransient(utils.GetAPIError(nil, testAPIError)), "expected an error without a response not to be transient")
+	})
+}
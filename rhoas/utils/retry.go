@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// transientError is an error after which the call can be tried again, for example because the object is still being
+// created or the server is temporarily unavailable
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// Transient marks err as transient, so that Retry tries the call again
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &transientError{err: err}
+}
+
+// IsTransient checks whether err, or an error it wraps, was marked as transient
+func IsTransient(err error) bool {
+	var transient *transientError
+	return errors.As(err, &transient)
+}
+
+// isTransientResponse checks whether the status code of the response means that the call can be tried again
+func isTransientResponse(response *http.Response) bool {
+	if response == nil {
+		return false
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// RetryError is returned by Retry when it gives up, it holds the error of every attempt
+type RetryError struct {
+	Attempts []error
+}
+
+func (e *RetryError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "giving up after %d attempts:", len(e.Attempts))
+
+	// consecutive attempts failing with the same error are listed once
+	for first := 0; first < len(e.Attempts); {
+		last := first
+		for last+1 < len(e.Attempts) && e.Attempts[last+1].Error() == e.Attempts[first].Error() {
+			last++
+		}
+
+		if first == last {
+			fmt.Fprintf(&b, "\n  attempt %d: %v", first+1, e.Attempts[first])
+		} else {
+			fmt.Fprintf(&b, "\n  attempts %d-%d: %v", first+1, last+1, e.Attempts[first])
+		}
+
+		first = last + 1
+	}
+
+	return b.String()
+}
+
+// Retry calls f until it succeeds or fails with an error that is not transient. Transient errors are retried with the
+// poll interval and backoff of the wait config until the deadline of ctx, after which a RetryError listing the error
+// of every attempt is returned.
+func (c WaitConfig) Retry(ctx context.Context, f func() error) error {
+	var attempts []error
+	interval := c.PollInterval
+
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+
+		// the call may have been cut short by the deadline, which is reported along with the earlier attempts
+		if !IsTransient(err) && (len(attempts) == 0 || ctx.Err() == nil) {
+			return err
+		}
+
+		attempts = append(attempts, err)
+
+		if deadline, ok := ctx.Deadline(); ctx.Err() != nil || (ok && time.Until(deadline) < interval) {
+			return &RetryError{Attempts: attempts}
+		}
+
+		log.Printf("[DEBUG] attempt %d failed, retrying in %s: %v", len(attempts), interval, err)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: attempts}
+		case <-timer.C:
+		}
+
+		interval = c.nextPollInterval(interval)
+	}
+}
@@ -0,0 +1,82 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+func TestRetry(t *testing.T) {
+	config := utils.WaitConfig{
+		PollInterval:      time.Millisecond,
+		MaxPollInterval:   2 * time.Millisecond,
+		BackoffMultiplier: 2,
+	}
+
+	t.Run("success after transient errors", func(t *testing.T) {
+		attempts := 0
+		err := config.Retry(context.Background(), func() error {
+			attempts++
+			if attempts < 3 {
+				return utils.Transient(errors.New("not ready yet"))
+			}
+			return nil
+		})
+		assert.NoError(t, err, "got unexpected error while retrying")
+		assert.Equal(t, 3, attempts, "expected the call to be retried until it succeeds")
+	})
+
+	t.Run("error that is not transient", func(t *testing.T) {
+		attempts := 0
+		notFound := errors.New("not found")
+		err := config.Retry(context.Background(), func() error {
+			attempts++
+			if attempts < 2 {
+				return utils.Transient(errors.New("not ready yet"))
+			}
+			return notFound
+		})
+		assert.Equal(t, notFound, err, "expected the error that is not transient to be returned as is")
+		assert.Equal(t, 2, attempts, "expected an error that is not transient not to be retried")
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		attempts := 0
+		err := config.Retry(ctx, func() error {
+			attempts++
+			if attempts == 1 {
+				return utils.Transient(errors.New("service unavailable"))
+			}
+			return utils.Transient(errors.New("not ready yet"))
+		})
+
+		var retryErr *utils.RetryError
+		assert.True(t, errors.As(err, &retryErr), "expected the retry to give up with a retry error")
+		assert.Len(t, retryErr.Attempts, attempts, "expected the error of every attempt to be kept")
+		assert.Contains(t, err.Error(), "attempt 1: service unavailable", "expected the error to list the first attempt")
+		assert.Contains(t, err.Error(), "not ready yet", "expected the error to list the later attempts")
+	})
+}
+
+func TestRetryErrorMessage(t *testing.T) {
+	err := &utils.RetryError{Attempts: []error{
+		errors.New("service unavailable"),
+		errors.New("not ready yet"),
+		errors.New("not ready yet"),
+		errors.New("not ready yet"),
+		errors.New("too many requests"),
+	}}
+
+	want := "giving up after 5 attempts:\n" +
+		"  attempt 1: service unavailable\n" +
+		"  attempts 2-4: not ready yet\n" +
+		"  attempt 5: too many requests"
+	assert.Equal(t, want, err.Error(), "unexpected retry error message")
+}
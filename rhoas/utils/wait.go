@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// MaxPollInterval is the largest interval between two polls supported by resource.StateChangeConf
+const MaxPollInterval = 180 * time.Second
+
+// WaitConfig configures how the API is polled while waiting for an object to change state
+type WaitConfig struct {
+	// PollInterval is the delay before the first poll and the initial interval between two polls
+	PollInterval time.Duration
+	// MaxPollInterval is the longest interval between two polls
+	MaxPollInterval time.Duration
+	// BackoffMultiplier is the factor the interval between two polls is multiplied by after every poll
+	BackoffMultiplier float64
+}
+
+// DefaultWaitConfig polls every 5 seconds at first, backing off to every 10 seconds
+var DefaultWaitConfig = WaitConfig{
+	PollInterval:      5 * time.Second,
+	MaxPollInterval:   10 * time.Second,
+	BackoffMultiplier: 2,
+}
+
+// StateChangeConf returns a StateChangeConf which polls refresh with an exponential backoff until the object reaches
+// one of the target states
+func (c WaitConfig) StateChangeConf(pending []string, target []string, refresh resource.StateRefreshFunc, timeout time.Duration) *resource.StateChangeConf {
+	conf := &resource.StateChangeConf{
+		Delay:        c.PollInterval,
+		Pending:      pending,
+		Target:       target,
+		Timeout:      timeout,
+		PollInterval: c.PollInterval,
+	}
+
+	// the StateChangeConf reads the poll interval after every refresh, so it is increased here for the next wait
+	interval := c.PollInterval
+	conf.Refresh = func() (interface{}, string, error) {
+		conf.PollInterval = interval
+		interval = c.nextPollInterval(interval)
+		return refresh()
+	}
+
+	return conf
+}
+
+func (c WaitConfig) nextPollInterval(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * c.BackoffMultiplier)
+	if next > c.MaxPollInterval {
+		return c.MaxPollInterval
+	}
+	return next
+}
@@ -0,0 +1,47 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"redhat.com/rhoas/rhoas-terraform-provider/m/rhoas/utils"
+)
+
+func TestWaitConfigStateChangeConf(t *testing.T) {
+	config := utils.WaitConfig{
+		PollInterval:      10 * time.Millisecond,
+		MaxPollInterval:   40 * time.Millisecond,
+		BackoffMultiplier: 2,
+	}
+
+	var intervals []time.Duration
+	polls := 0
+
+	conf := config.StateChangeConf([]string{"pending"}, []string{"done"}, func() (interface{}, string, error) {
+		polls++
+		if polls == 5 {
+			return polls, "done", nil
+		}
+		return polls, "pending", nil
+	}, time.Minute)
+
+	refresh := conf.Refresh
+	conf.Refresh = func() (interface{}, string, error) {
+		result, state, err := refresh()
+		intervals = append(intervals, conf.PollInterval)
+		return result, state, err
+	}
+
+	result, err := conf.WaitForStateContext(context.Background())
+	assert.NoError(t, err, "got unexpected error while waiting")
+	assert.Equal(t, 5, result, "expected the wait to end with the target state")
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		40 * time.Millisecond,
+		40 * time.Millisecond,
+	}, intervals, "expected the poll interval to back off exponentially up to the maximum")
+}